@@ -166,6 +166,28 @@ func BenchmarkSlogText10Fields(b *testing.B) {
 	})
 }
 
+func BenchmarkSlogText10TypedFields(b *testing.B) {
+	l := slog.New()
+	l.RegisterHandler(slog.DebugLevel, discard.Default)
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			l.With(
+				slog.Int("int", 1),
+				slog.Int64("int64", 1),
+				slog.Float64("float", 3.0),
+				slog.String("string", "four!"),
+				slog.Bool("bool", true),
+				slog.Time("time", time.Unix(0, 0)),
+				slog.String("error", errExample.Error()),
+				slog.Duration("duration", time.Second),
+				slog.Any("user-defined type", _jane),
+				slog.String("another string", "done!"),
+			).Info("Go fast.")
+		}
+	})
+}
+
 func BenchmarkSlogTextSimple(b *testing.B) {
 	l := slog.New()
 	l.RegisterHandler(slog.DebugLevel, discard.Default)
@@ -0,0 +1,140 @@
+package slog
+
+import (
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// pkgPath is used to recognize, and skip, the frames of this package when
+// looking for the caller of a log method.
+var pkgPath = reflect.TypeOf(Logger{}).PkgPath()
+
+// Caller describes the source location of a log call.
+type Caller struct {
+	File     string `json:"file"`
+	Line     int    `json:"line"`
+	Function string `json:"function"`
+}
+
+// String returns the file, trimmed to its parent directory, and line of the
+// call, e.g. "slog/entry.go:42".
+func (c Caller) String() string {
+	dir, file := filepath.Split(c.File)
+	return filepath.Join(filepath.Base(dir), file) + ":" + strconv.Itoa(c.Line)
+}
+
+// SetReportCaller enables or disables capturing the source location of each
+// log call in Entry.Caller. It is disabled by default as it is not free. It
+// should be called before the Logger is used.
+func (l *Logger) SetReportCaller(enabled bool) *Logger {
+	l.reportCaller = enabled
+	return l
+}
+
+// SetCallerSkip sets the number of additional stack frames to skip when
+// reporting the caller. The frames of this package, including those of Writer,
+// Trace and Stop, are always skipped, so this is only needed for functions
+// that wrap a Logger or Entry. It should be called before the Logger is used.
+func (l *Logger) SetCallerSkip(skip int) *Logger {
+	l.callerSkip = skip
+	return l
+}
+
+// inPackage reports whether the fully qualified function name belongs to this
+// package (and not one of its subpackages).
+func inPackage(function string) bool {
+	return strings.HasPrefix(function, pkgPath+".")
+}
+
+// inStdLog reports whether the fully qualified function name belongs to the
+// standard log package, whose frames precede this package's when it is
+// redirected with RedirectLogger.
+func inStdLog(function string) bool {
+	return strings.HasPrefix(function, "log.")
+}
+
+// inFmt reports whether the fully qualified function name belongs to the
+// standard fmt package, whose frames precede this package's when printing to a
+// Writer.
+func inFmt(function string) bool {
+	return strings.HasPrefix(function, "fmt.")
+}
+
+// caller returns the first frame outside of this package, after skipping an
+// additional `skip` frames, along with a program counter for it in the form
+// returned by runtime.Callers.
+func caller(skip int) (*Caller, uintptr) {
+	f := frames(skip, 1)
+	if len(f) == 0 {
+		return nil, 0
+	}
+
+	c := frameCaller(f[0])
+
+	// the PC of a frame is that of the call instruction, or of the call site
+	// of an inlined function, which runtime.Callers would return plus one
+	return &c, f[0].PC + 1
+}
+
+// pcCaller returns the location of the call at pc, as returned by
+// runtime.Callers.
+func pcCaller(pc uintptr) *Caller {
+	frame, _ := runtime.CallersFrames([]uintptr{pc}).Next()
+	c := frameCaller(frame)
+	return &c
+}
+
+// callers returns up to `depth` frames of the current goroutine's stack,
+// starting with the first frame outside of this package, after skipping an
+// additional `skip` frames.
+func callers(skip, depth int) Stack {
+	f := frames(skip, depth)
+
+	s := make(Stack, len(f))
+	for i, frame := range f {
+		s[i] = frameCaller(frame)
+	}
+
+	return s
+}
+
+// frames returns the frames for callers.
+func frames(skip, depth int) []runtime.Frame {
+	var pcs [64]uintptr
+
+	// skip runtime.Callers and frames
+	n := runtime.Callers(2, pcs[:])
+	frames := runtime.CallersFrames(pcs[:n])
+
+	var ret []runtime.Frame
+	for len(ret) < depth {
+		frame, more := frames.Next()
+
+		switch {
+		case len(ret) > 0:
+			ret = append(ret, frame)
+		case inPackage(frame.Function), inStdLog(frame.Function), inFmt(frame.Function):
+		case skip > 0:
+			skip--
+		default:
+			ret = append(ret, frame)
+		}
+
+		if !more {
+			break
+		}
+	}
+
+	return ret
+}
+
+func frameCaller(frame runtime.Frame) Caller {
+	return Caller{
+		File:     frame.File,
+		Line:     frame.Line,
+		Function: frame.Function,
+	}
+}
@@ -0,0 +1,99 @@
+package slog_test
+
+import (
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/joshuarubin/slog"
+	"github.com/joshuarubin/slog/handlers/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+func line() int {
+	_, _, line, _ := runtime.Caller(1)
+	return line
+}
+
+func TestLogger_ReportCaller(t *testing.T) {
+	h := memory.New()
+
+	l := slog.New().SetReportCaller(true)
+	l.RegisterHandler(slog.InfoLevel, h)
+
+	l.Info("logger")
+	expect := []int{line() - 1}
+
+	l.WithField("file", "sloth.png").Info("entry")
+	expect = append(expect, line()-1)
+
+	func() {
+		defer l.Trace(slog.InfoLevel, "trace").Stop(nil)
+		expect = append(expect, line()-1, line()+1)
+	}()
+
+	w := l.Writer(slog.InfoLevel)
+	_, _ = w.Write([]byte("writer\n"))
+	expect = append(expect, line()-1)
+
+	fmt.Fprintln(w, "fprintln")
+	expect = append(expect, line()-1)
+
+	assert.Equal(t, len(expect), len(h.Entries))
+
+	for i, e := range h.Entries {
+		if assert.NotNil(t, e.Caller, e.Message) {
+			assert.Equal(t, "caller_test.go", filepath.Base(e.Caller.File))
+			assert.Equal(t, expect[i], e.Caller.Line, e.Message)
+			assert.Contains(t, e.Caller.Function, "TestLogger_ReportCaller")
+		}
+	}
+}
+
+func TestLogger_CallerSkip(t *testing.T) {
+	h := memory.New()
+
+	l := slog.New().SetReportCaller(true).SetCallerSkip(1)
+	l.RegisterHandler(slog.InfoLevel, h)
+
+	wrapper := func(msg string) {
+		l.Info(msg)
+	}
+
+	wrapper("skipped")
+	expect := line() - 1
+
+	assert.Equal(t, 1, len(h.Entries))
+	assert.Equal(t, expect, h.Entries[0].Caller.Line)
+}
+
+func TestEntry_WithCallerPC(t *testing.T) {
+	h := memory.New()
+
+	l := slog.New().SetReportCaller(true)
+	l.RegisterHandler(slog.InfoLevel, h)
+
+	var pcs [1]uintptr
+	runtime.Callers(1, pcs[:])
+	expect := line() - 1
+
+	l.WithField("file", "sloth.png").WithCallerPC(pcs[0]).Info("pc")
+	slog.NewEntry(l).WithCallerPC(0).Info("zero")
+	zero := line() - 1
+
+	assert.Equal(t, expect, h.Entries[0].Caller.Line)
+	assert.Contains(t, h.Entries[0].Caller.Function, "TestEntry_WithCallerPC")
+	assert.Equal(t, zero, h.Entries[1].Caller.Line)
+}
+
+func TestLogger_ReportCaller_disabled(t *testing.T) {
+	h := memory.New()
+
+	l := slog.New()
+	l.RegisterHandler(slog.InfoLevel, h)
+
+	l.Info("no caller")
+
+	assert.Nil(t, h.Entries[0].Caller)
+}
@@ -0,0 +1,47 @@
+package slog
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+type contextKey struct{}
+
+var defaultLogger atomic.Value
+
+func init() {
+	defaultLogger.Store(Nil)
+}
+
+// SetDefault sets the Logger used by FromContext when the context does not
+// carry an Entry. Passing nil restores the Nil logger.
+func SetDefault(l *Logger) {
+	if l == nil {
+		l = Nil
+	}
+
+	defaultLogger.Store(l)
+}
+
+// Default returns the Logger used by FromContext when the context does not
+// carry an Entry.
+func Default() *Logger {
+	return defaultLogger.Load().(*Logger)
+}
+
+// NewContext returns a copy of ctx that carries e, including all of the fields
+// that have been added to it.
+func NewContext(ctx context.Context, e *Entry) context.Context {
+	return context.WithValue(ctx, contextKey{}, e)
+}
+
+// FromContext returns the Entry carried by ctx, or a new Entry for the Default
+// logger if there is none. The returned Entry carries ctx so that handlers can
+// access request scoped values through Entry.Context.
+func FromContext(ctx context.Context) *Entry {
+	if e, ok := ctx.Value(contextKey{}).(*Entry); ok && e != nil {
+		return e.WithContext(ctx)
+	}
+
+	return NewEntry(Default()).WithContext(ctx)
+}
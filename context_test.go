@@ -0,0 +1,72 @@
+package slog_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/joshuarubin/slog"
+	"github.com/joshuarubin/slog/handlers/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+type ctxKey struct{}
+
+func TestContext(t *testing.T) {
+	h := memory.New()
+
+	l := slog.New()
+	l.RegisterHandler(slog.InfoLevel, h)
+
+	ctx := slog.NewContext(context.Background(), l.WithField("user", "Tobi"))
+	ctx = context.WithValue(ctx, ctxKey{}, "abc123")
+
+	slog.FromContext(ctx).WithField("file", "sloth.png").Info("upload")
+
+	assert.Equal(t, 1, len(h.Entries))
+
+	e := h.Entries[0]
+	assert.Equal(t, "upload", e.Message)
+	assert.Equal(t, slog.Fields{"user": "Tobi", "file": "sloth.png"}, e.Fields)
+	assert.Equal(t, "abc123", e.Context.Value(ctxKey{}))
+}
+
+func TestContext_Trace(t *testing.T) {
+	h := memory.New()
+
+	l := slog.New()
+	l.RegisterHandler(slog.InfoLevel, h)
+
+	ctx := slog.NewContext(context.Background(), l.WithField("user", "Tobi"))
+
+	func() {
+		defer slog.FromContext(ctx).Trace(slog.InfoLevel, "upload").Stop(nil)
+	}()
+
+	w := slog.FromContext(ctx).Writer(slog.InfoLevel)
+	_, _ = w.Write([]byte("written\n"))
+	_ = w.Close()
+
+	assert.Equal(t, 3, len(h.Entries))
+
+	for _, e := range h.Entries {
+		assert.Equal(t, "Tobi", e.Fields["user"])
+		assert.Equal(t, ctx, e.Context)
+	}
+}
+
+func TestContext_default(t *testing.T) {
+	h := memory.New()
+
+	l := slog.New()
+	l.RegisterHandler(slog.InfoLevel, h)
+
+	assert.Equal(t, slog.Nil, slog.Default())
+
+	slog.SetDefault(l)
+	defer slog.SetDefault(nil)
+
+	slog.FromContext(context.Background()).Info("hello")
+
+	assert.Equal(t, 1, len(h.Entries))
+	assert.Equal(t, "hello", h.Entries[0].Message)
+}
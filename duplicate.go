@@ -0,0 +1,62 @@
+package slog
+
+import "strconv"
+
+// DuplicatePolicy determines which field is kept when an entry has more than
+// one field with the same key.
+type DuplicatePolicy int
+
+// Duplicate policies.
+const (
+	// LastWins keeps the value of the field added last, at the position the
+	// key was first added. It is the default.
+	LastWins DuplicatePolicy = iota
+
+	// FirstWins keeps the field added first and ignores later ones.
+	FirstWins
+
+	// KeepBoth keeps every field, renaming later ones by adding a suffix of
+	// "_1", "_2" and so on to the key.
+	KeepBoth
+)
+
+var duplicatePolicyNames = [...]string{
+	LastWins:  "last-wins",
+	FirstWins: "first-wins",
+	KeepBoth:  "keep-both",
+}
+
+// String implements fmt.Stringer.
+func (p DuplicatePolicy) String() string {
+	if p < 0 || int(p) >= len(duplicatePolicyNames) {
+		return "DuplicatePolicy(" + strconv.Itoa(int(p)) + ")"
+	}
+
+	return duplicatePolicyNames[p]
+}
+
+// SetDuplicatePolicy sets how fields with the same key are handled when an
+// entry is logged. It should be called before the Logger is used.
+func (l *Logger) SetDuplicatePolicy(p DuplicatePolicy) *Logger {
+	l.duplicates = p
+	return l
+}
+
+// duplicatePolicy returns the policy of the entry's Logger.
+func (e *Entry) duplicatePolicy() DuplicatePolicy {
+	if e.Logger == nil {
+		return LastWins
+	}
+
+	return e.Logger.duplicates
+}
+
+// uniqueKey returns key with the lowest numbered suffix that is not in index.
+func uniqueKey(index map[string]int, key string) string {
+	for n := 1; ; n++ {
+		k := key + "_" + strconv.Itoa(n)
+		if _, ok := index[k]; !ok {
+			return k
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package slog_test
+
+import (
+	"testing"
+
+	"github.com/joshuarubin/slog"
+	"github.com/joshuarubin/slog/handlers/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLogger_SetDuplicatePolicy(t *testing.T) {
+	log := func(p slog.DuplicatePolicy) *slog.Entry {
+		h := memory.New()
+
+		l := slog.New().SetDuplicatePolicy(p)
+		l.RegisterHandler(slog.InfoLevel, h)
+
+		l.WithField("user", "tobi").
+			With(slog.String("file", "sloth.png")).
+			WithField("user", "loki").
+			With(slog.String("user", "jane")).
+			Info("upload")
+
+		return h.Entries[0]
+	}
+
+	assert.Equal(t, []slog.Field{
+		slog.Any("user", "tobi"),
+		slog.String("file", "sloth.png"),
+	}, log(slog.FirstWins).FieldList)
+
+	e := log(slog.LastWins)
+	assert.Equal(t, []slog.Field{
+		slog.String("user", "jane"),
+		slog.String("file", "sloth.png"),
+	}, e.FieldList)
+	assert.Equal(t, slog.Fields{"user": "jane", "file": "sloth.png"}, e.Fields)
+
+	e = log(slog.KeepBoth)
+	assert.Equal(t, []slog.Field{
+		slog.Any("user", "tobi"),
+		slog.String("file", "sloth.png"),
+		slog.Any("user_1", "loki"),
+		slog.String("user_2", "jane"),
+	}, e.FieldList)
+	assert.Equal(t, slog.Fields{
+		"user":   "tobi",
+		"file":   "sloth.png",
+		"user_1": "loki",
+		"user_2": "jane",
+	}, e.Fields)
+
+	assert.Equal(t, "keep-both", slog.KeepBoth.String())
+	assert.Equal(t, "DuplicatePolicy(7)", slog.DuplicatePolicy(7).String())
+}
+
+func TestLogger_Logw_duplicates(t *testing.T) {
+	h := memory.New()
+
+	l := slog.New().SetDuplicatePolicy(slog.KeepBoth)
+	l.RegisterHandler(slog.InfoLevel, h)
+
+	l.Infow("odd", 1, "user", "tobi", 2)
+
+	assert.Equal(t, []slog.Field{
+		slog.Any("!BADKEY", 1),
+		slog.Any("user", "tobi"),
+		slog.Any("!BADKEY_1", 2),
+	}, h.Entries[0].FieldList)
+}
@@ -1,24 +1,32 @@
 package slog
 
 import (
-	"errors"
-	"os"
+	"context"
 	"time"
 )
 
 // assert interface compliance.
 var _ Interface = (*Entry)(nil)
 
-// Entry represents a single log entry.
+// Entry represents a single log entry. FieldList holds every field of a
+// finalized entry in the order they were added and must not be modified.
+// Fields holds the same fields as a map, for handlers that predate FieldList
+// and for encoding an Entry with encoding/json.
 type Entry struct {
-	Logger     *Logger   `json:"-"`
-	Fields     Fields    `json:"fields"`
-	Level      Level     `json:"level"`
-	Time       time.Time `json:"time"`
-	Message    string    `json:"msg"`
+	Logger     *Logger         `json:"-"`
+	Context    context.Context `json:"-"`
+	Fields     Fields          `json:"fields"`
+	FieldList  []Field         `json:"-"`
+	Level      Level           `json:"level"`
+	Time       time.Time       `json:"time"`
+	Message    string          `json:"msg"`
+	Caller     *Caller         `json:"caller,omitempty"`
+	Stack      Stack           `json:"stack,omitempty"`
 	start      time.Time
-	fields     []Fields
+	fields     []Field
 	traceLevel Level
+	pc         uintptr
+	timestamp  time.Time
 }
 
 // NewEntry returns a new entry for `log`.
@@ -28,20 +36,74 @@ func NewEntry(log *Logger) *Entry {
 	}
 }
 
-// WithFields returns a new entry with `fields` set.
-func (e *Entry) WithFields(fields Fielder) *Entry {
+// clone returns a copy of the entry that can be modified without affecting e.
+func (e *Entry) clone() *Entry {
 	return &Entry{
 		Logger:     e.Logger,
+		Context:    e.Context,
 		Message:    e.Message,
 		start:      e.start,
-		fields:     append(e.fields, fields.Fields()),
+		fields:     e.fields[:len(e.fields):len(e.fields)],
 		traceLevel: e.traceLevel,
+		pc:         e.pc,
+		timestamp:  e.timestamp,
 	}
 }
 
+// WithFields returns a new entry with `fields` set. As maps are unordered,
+// the fields are added in order of their keys.
+func (e *Entry) WithFields(fields Fielder) *Entry {
+	return e.With(mapFields(fields.Fields())...)
+}
+
+// With returns a new entry with the typed `fields` added.
+func (e *Entry) With(fields ...Field) *Entry {
+	v := e.clone()
+	v.fields = append(v.fields, fields...)
+	return v
+}
+
+// WithContext returns a new entry that carries `ctx`. Handlers can use it to
+// access request scoped values.
+func (e *Entry) WithContext(ctx context.Context) *Entry {
+	v := e.clone()
+	v.Context = ctx
+	return v
+}
+
+// WithCallerPC returns a new entry that, when the Logger reports callers, has
+// the call at pc, as returned by runtime.Callers, as its Caller rather than the
+// call to the log method. It is for adapters, such as stdslog, that are passed
+// the location of the original call. A pc of zero is ignored.
+func (e *Entry) WithCallerPC(pc uintptr) *Entry {
+	v := e.clone()
+	v.pc = pc
+	return v
+}
+
+// CallerPC returns the program counter of Caller, in the form returned by
+// runtime.Callers, or zero if Caller was not set by the Logger. Adapters such
+// as stdslog use it to pass the caller on.
+func (e *Entry) CallerPC() uintptr {
+	if e.Caller == nil {
+		return 0
+	}
+
+	return e.pc
+}
+
+// WithTime returns a new entry that is logged with t as its Time rather than
+// the time it is logged. It is for adapters, such as stdslog, that are passed
+// the time of the original call. A zero t is ignored.
+func (e *Entry) WithTime(t time.Time) *Entry {
+	v := e.clone()
+	v.timestamp = t
+	return v
+}
+
 // WithField returns a new entry with the `key` and `value` set.
 func (e *Entry) WithField(key string, value interface{}) *Entry {
-	return e.WithFields(Fields{key: value})
+	return e.With(Any(key, value))
 }
 
 // WithError returns a new entry with the "error" set to `err`.
@@ -49,6 +111,13 @@ func (e *Entry) WithError(err error) *Entry {
 	return e.WithField("error", err)
 }
 
+// Log a message at level, which may be a custom level registered with
+// RegisterLevel. Unlike Fatal and Panic, logging at FatalLevel or PanicLevel
+// does not exit or panic.
+func (e *Entry) Log(level Level, msg string) {
+	e.Logger.log(level, e, msg)
+}
+
 // Debug level message.
 func (e *Entry) Debug(msg string) {
 	e.Logger.log(DebugLevel, e, msg)
@@ -69,19 +138,25 @@ func (e *Entry) Error(msg string) {
 	e.Logger.log(ErrorLevel, e, msg)
 }
 
-// Fatal level message, followed by an exit.
+// Fatal level message, followed by flushing the handlers and an exit.
 func (e *Entry) Fatal(msg string) {
 	if e.Logger != Nil {
 		e.Logger.log(FatalLevel, e, msg)
-		os.Exit(1)
+		e.Logger.flush()
+		e.Logger.exit(1)
 	}
 }
 
-// Panic level message, followed by a panic.
+// Panic level message, followed by flushing the handlers and a panic.
 func (e *Entry) Panic(msg string) {
 	if e.Logger != Nil {
-		e.Logger.log(PanicLevel, e, msg)
-		panic(errors.New(msg))
+		f := e.Logger.log(PanicLevel, e, msg)
+		if f == nil {
+			f = e.Logger.finalize(PanicLevel, e, msg)
+		}
+
+		e.Logger.flush()
+		e.Logger.panic(f)
 	}
 }
 
@@ -98,7 +173,7 @@ func (e *Entry) IfError(err error) Interface {
 // a corresponding completion log, useful with defer.
 func (e *Entry) Trace(level Level, msg string) *Entry {
 	e.Logger.log(level, e, msg)
-	v := e.WithFields(e.Fields)
+	v := e.clone()
 	v.Message = msg
 	v.start = time.Now()
 	v.traceLevel = level
@@ -118,26 +193,89 @@ func (e *Entry) Stop(err *error) {
 	v.WithError(*err).Error(e.Message)
 }
 
-// mergedFields returns the fields list collapsed into a single map.
-func (e *Entry) mergedFields() Fields {
-	f := Fields{}
+// Enabled reports whether any handler accepts entries at level.
+func (e *Entry) Enabled(level Level) bool {
+	return e.Logger.Enabled(level)
+}
+
+// resolvedFields returns the fields list with any LazyValue resolved and keys
+// that were added more than once handled according to the DuplicatePolicy of
+// the Logger.
+func (e *Entry) resolvedFields() []Field {
+	list := e.fields
+	if len(list) == 0 {
+		return nil
+	}
+
+	if !needsResolve(list) {
+		return list
+	}
+
+	policy := e.duplicatePolicy()
+	ret := make([]Field, 0, len(list))
+	index := make(map[string]int, len(list))
+
+	for _, f := range list {
+		f = f.resolve()
+
+		i, ok := index[f.Key]
+		if ok {
+			switch policy {
+			case FirstWins:
+				continue
+			case KeepBoth:
+				f.Key = uniqueKey(index, f.Key)
+			default:
+				ret[i] = f
+				continue
+			}
+		}
+
+		index[f.Key] = len(ret)
+		ret = append(ret, f)
+	}
+
+	return ret
+}
+
+// needsResolve reports whether list has a LazyValue or duplicate keys. Long
+// lists are always resolved rather than compared pairwise.
+func needsResolve(list []Field) bool {
+	if len(list) > 32 {
+		return true
+	}
 
-	for _, fields := range e.fields {
-		for k, v := range fields {
-			f[k] = v
+	for i, f := range list {
+		if f.isLazy() {
+			return true
+		}
+
+		for _, g := range list[:i] {
+			if g.Key == f.Key {
+				return true
+			}
 		}
 	}
 
-	return f
+	return false
 }
 
-// finalize returns a copy of the Entry with Fields merged.
+// finalize returns a copy of the Entry with fields resolved.
 func (e *Entry) finalize(level Level, msg string) *Entry {
+	list := e.resolvedFields()
+
+	t := e.timestamp
+	if t.IsZero() {
+		t = time.Now()
+	}
+
 	return &Entry{
-		Logger:  e.Logger,
-		Fields:  e.mergedFields(),
-		Level:   level,
-		Message: msg,
-		Time:    time.Now(),
+		Logger:    e.Logger,
+		Context:   e.Context,
+		Fields:    fieldsMap(list),
+		FieldList: list,
+		Level:     level,
+		Message:   msg,
+		Time:      t,
 	}
 }
@@ -3,6 +3,7 @@ package slog
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -12,8 +13,8 @@ func TestEntry_WithFields(t *testing.T) {
 	assert.Nil(t, a.Fields)
 
 	b := a.WithFields(Fields{"foo": "bar"})
-	assert.Equal(t, Fields{}, a.mergedFields())
-	assert.Equal(t, Fields{"foo": "bar"}, b.mergedFields())
+	assert.Equal(t, Fields{}, a.finalize(InfoLevel, "x").Fields)
+	assert.Equal(t, Fields{"foo": "bar"}, b.finalize(InfoLevel, "x").Fields)
 
 	c := a.WithFields(Fields{"foo": "hello", "bar": "world"})
 
@@ -27,14 +28,41 @@ func TestEntry_WithFields(t *testing.T) {
 func TestEntry_WithField(t *testing.T) {
 	a := NewEntry(nil)
 	b := a.WithField("foo", "bar")
-	assert.Equal(t, Fields{}, a.mergedFields())
-	assert.Equal(t, Fields{"foo": "bar"}, b.mergedFields())
+	assert.Equal(t, Fields{}, a.finalize(InfoLevel, "x").Fields)
+	assert.Equal(t, Fields{"foo": "bar"}, b.finalize(InfoLevel, "x").Fields)
 }
 
 func TestEntry_WithError(t *testing.T) {
 	a := NewEntry(nil)
 	b := a.WithError(fmt.Errorf("boom"))
-	assert.Equal(t, Fields{}, a.mergedFields())
-	assert.Equal(t, 1, len(b.mergedFields()))
-	assert.Equal(t, "boom", b.mergedFields()["error"].(error).Error())
+	assert.Equal(t, Fields{}, a.finalize(InfoLevel, "x").Fields)
+	assert.Equal(t, 1, len(b.finalize(InfoLevel, "x").Fields))
+	assert.Equal(t, "boom", b.finalize(InfoLevel, "x").Fields["error"].(error).Error())
+}
+
+func TestEntry_WithTime(t *testing.T) {
+	at := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	e := NewEntry(nil).WithTime(at).WithField("foo", "bar").finalize(InfoLevel, "x")
+	assert.Equal(t, at, e.Time)
+
+	e = NewEntry(nil).WithTime(time.Time{}).finalize(InfoLevel, "x")
+	assert.False(t, e.Time.IsZero())
+}
+
+func TestEntry_finalize_lazy(t *testing.T) {
+	var calls int
+	a := NewEntry(nil).WithFields(Fields{
+		"lazy": LazyValue(func() interface{} {
+			calls++
+			return "resolved"
+		}),
+		"func": func() interface{} { return 42 },
+	})
+
+	assert.Equal(t, 0, calls)
+
+	e := a.finalize(InfoLevel, "lazy")
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, Fields{"lazy": "resolved", "func": 42}, e.Fields)
 }
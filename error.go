@@ -0,0 +1,173 @@
+package slog
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"sync/atomic"
+	"time"
+)
+
+// Defaults for SetErrorLimit.
+const (
+	DefaultErrorLimit    = 10
+	DefaultErrorInterval = time.Second
+)
+
+// errorLog reports errors from handlers by default. It writes to stderr
+// directly rather than through the standard logger, which may be redirected to
+// a Logger.
+var errorLog = log.New(os.Stderr, "", log.LstdFlags)
+
+// ErrorHandler is called with the Handler, the Entry it was passed and the
+// error it returned when a Handler fails to log an entry. The Entry is nil for
+// errors from flushing the handlers before Fatal exits or Panic panics.
+type ErrorHandler func(h Handler, e *Entry, err error)
+
+// ErrorsSuppressed is passed to the ErrorHandler, with a nil Handler and
+// Entry, at the start of an interval that follows one in which errors were
+// dropped by the limit set with SetErrorLimit. Its value is the number of
+// errors that were dropped.
+type ErrorsSuppressed int
+
+func (n ErrorsSuppressed) Error() string {
+	return fmt.Sprintf("%d errors suppressed", int(n))
+}
+
+// defaultErrorHandler prints the error to stderr.
+func defaultErrorHandler(_ Handler, e *Entry, err error) {
+	if _, ok := err.(ErrorsSuppressed); ok {
+		errorLog.Print(err)
+		return
+	}
+
+	if e == nil {
+		errorLog.Printf("error flushing: %s", err)
+		return
+	}
+
+	errorLog.Printf("error logging: %s", err)
+}
+
+// SetErrorHandler sets the function called when a Handler returns an error. It
+// defaults to printing the error to stderr. Passing nil restores the default.
+// It should be called before the Logger is used.
+func (l *Logger) SetErrorHandler(fn ErrorHandler) *Logger {
+	l.errorHandler = fn
+	return l
+}
+
+// SetErrorLimit limits the errors passed to the ErrorHandler to n in each
+// interval, so that a failing handler doesn't flood the error output. Errors
+// over the limit are still counted by Registration.Errors, and their number is
+// reported as ErrorsSuppressed once the interval has ended. It defaults to
+// DefaultErrorLimit errors each DefaultErrorInterval, and an n of less than 1
+// disables the limit. It should be called before the Logger is used.
+func (l *Logger) SetErrorLimit(n int, interval time.Duration) *Logger {
+	if n < 1 {
+		n = -1
+	}
+
+	l.errorLimit = n
+	l.errorInterval = interval
+	return l
+}
+
+// Errors returns the number of errors the handler has returned.
+func (r *Registration) Errors() uint64 {
+	return atomic.LoadUint64(&r.errors)
+}
+
+// ReportError handles err, returned by h when logging e, as if h had returned it
+// from HandleLog: it is counted by the Registration of h and passed to the
+// ErrorHandler. It is for handlers, such as async, that log entries after
+// HandleLog has returned.
+func (l *Logger) ReportError(h Handler, e *Entry, err error) {
+	for _, r := range l.registrations() {
+		if sameHandler(r.handler, h) {
+			atomic.AddUint64(&r.errors, 1)
+		}
+	}
+
+	l.reportError(h, e, err)
+}
+
+// sameHandler reports whether a and b are the same handler. Handlers that can't
+// be compared, such as a HandlerFunc, are never the same.
+func sameHandler(a, b Handler) (ok bool) {
+	defer func() {
+		if recover() != nil {
+			ok = false
+		}
+	}()
+
+	return a == b
+}
+
+// handleError counts err against r and reports it.
+func (l *Logger) handleError(r *Registration, e *Entry, err error) {
+	atomic.AddUint64(&r.errors, 1)
+	l.reportError(r.handler, e, err)
+}
+
+// reportError passes err to the ErrorHandler unless the limit has been
+// reached, preceded by the number of errors that were suppressed in the last
+// interval, if any.
+func (l *Logger) reportError(h Handler, e *Entry, err error) {
+	ok, suppressed := l.allowError()
+	if !ok {
+		return
+	}
+
+	fn := l.errorHandler
+	if fn == nil {
+		fn = defaultErrorHandler
+	}
+
+	if suppressed > 0 {
+		fn(nil, nil, ErrorsSuppressed(suppressed))
+	}
+
+	fn(h, e, err)
+}
+
+// allowError reports whether another error may be passed to the ErrorHandler
+// in the current interval, and, at the start of an interval, how many errors
+// were over the limit in the last one.
+func (l *Logger) allowError() (ok bool, suppressed int) {
+	limit, interval := l.errorLimit, l.errorInterval
+
+	switch {
+	case limit < 0:
+		return true, 0
+	case limit == 0:
+		limit = DefaultErrorLimit
+	}
+
+	if interval <= 0 {
+		interval = DefaultErrorInterval
+	}
+
+	clock := time.Now
+	if l.errorNow != nil {
+		clock = l.errorNow
+	}
+
+	now := clock()
+
+	l.errorMu.Lock()
+	defer l.errorMu.Unlock()
+
+	if now.Sub(l.errorStart) >= interval {
+		if l.errorCount > limit {
+			suppressed = l.errorCount - limit
+		}
+
+		l.errorStart = now
+		l.errorCount = 0
+	}
+
+	l.errorCount++
+
+	return l.errorCount <= limit, suppressed
+}
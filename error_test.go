@@ -0,0 +1,152 @@
+package slog_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/joshuarubin/slog"
+	"github.com/joshuarubin/slog/handlers/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+var errBoom = errors.New("boom")
+
+func failing(e *slog.Entry) error {
+	return errBoom
+}
+
+type reported struct {
+	handler slog.Handler
+	entry   *slog.Entry
+	err     error
+}
+
+func TestLogger_SetErrorHandler(t *testing.T) {
+	var reports []reported
+
+	l := slog.New().SetErrorHandler(func(h slog.Handler, e *slog.Entry, err error) {
+		reports = append(reports, reported{h, e, err})
+	})
+
+	m := memory.New()
+	ok := l.Register(slog.InfoLevel, m)
+	bad := l.Register(slog.InfoLevel, slog.HandlerFunc(failing))
+
+	l.Info("upload")
+
+	assert.Equal(t, 1, len(reports))
+	assert.IsType(t, slog.HandlerFunc(nil), reports[0].handler)
+	assert.Equal(t, m.Entries[0], reports[0].entry)
+	assert.Equal(t, errBoom, reports[0].err)
+
+	assert.Equal(t, uint64(0), ok.Errors())
+	assert.Equal(t, uint64(1), bad.Errors())
+}
+
+func TestLogger_SetErrorLimit(t *testing.T) {
+	var calls int
+	count := func(slog.Handler, *slog.Entry, error) { calls++ }
+
+	l := slog.New().SetErrorHandler(count)
+	r := l.Register(slog.InfoLevel, slog.HandlerFunc(failing))
+
+	for i := 0; i < 20; i++ {
+		l.Info("upload")
+	}
+
+	assert.Equal(t, slog.DefaultErrorLimit, calls)
+	assert.Equal(t, uint64(20), r.Errors())
+
+	calls = 0
+	l = slog.New().SetErrorHandler(count).SetErrorLimit(2, time.Hour)
+	l.RegisterHandler(slog.InfoLevel, slog.HandlerFunc(failing))
+
+	for i := 0; i < 5; i++ {
+		l.Info("upload")
+	}
+
+	assert.Equal(t, 2, calls)
+
+	calls = 0
+	l = slog.New().SetErrorHandler(count).SetErrorLimit(0, 0)
+	l.RegisterHandler(slog.InfoLevel, slog.HandlerFunc(failing))
+
+	for i := 0; i < 50; i++ {
+		l.Info("upload")
+	}
+
+	assert.Equal(t, 50, calls)
+}
+
+func TestLogger_SetErrorLimit_suppressed(t *testing.T) {
+	var reports []reported
+
+	now := time.Now()
+
+	l := slog.New().
+		SetErrorLimit(2, time.Second).
+		SetErrorClock(func() time.Time { return now }).
+		SetErrorHandler(func(h slog.Handler, e *slog.Entry, err error) {
+			reports = append(reports, reported{h, e, err})
+		})
+	l.RegisterHandler(slog.InfoLevel, slog.HandlerFunc(failing))
+
+	for i := 0; i < 5; i++ {
+		l.Info("upload")
+	}
+
+	assert.Equal(t, 2, len(reports))
+
+	// the interval ends, the next error is preceded by the count
+	now = now.Add(time.Second)
+	l.Info("upload")
+
+	if assert.Equal(t, 4, len(reports)) {
+		assert.Nil(t, reports[2].handler)
+		assert.Nil(t, reports[2].entry)
+		assert.Equal(t, slog.ErrorsSuppressed(3), reports[2].err)
+		assert.EqualError(t, reports[2].err, "3 errors suppressed")
+		assert.Equal(t, errBoom, reports[3].err)
+	}
+}
+
+func TestLogger_ReportError(t *testing.T) {
+	var reports []reported
+
+	l := slog.New().SetErrorHandler(func(h slog.Handler, e *slog.Entry, err error) {
+		reports = append(reports, reported{h, e, err})
+	})
+
+	m := memory.New()
+	r := l.Register(slog.InfoLevel, m)
+	l.RegisterHandler(slog.InfoLevel, slog.HandlerFunc(failing))
+
+	e := &slog.Entry{Message: "upload"}
+	l.ReportError(m, e, errBoom)
+	l.ReportError(slog.HandlerFunc(failing), e, errBoom)
+
+	assert.Equal(t, 2, len(reports))
+	assert.Equal(t, reported{m, e, errBoom}, reports[0])
+	assert.Equal(t, uint64(1), r.Errors())
+}
+
+func TestLogger_SetErrorHandler_flush(t *testing.T) {
+	var reports []reported
+
+	l := slog.New().
+		SetExitFunc(func(int) {}).
+		SetErrorHandler(func(h slog.Handler, e *slog.Entry, err error) {
+			reports = append(reports, reported{h, e, err})
+		})
+
+	f := &flusher{Handler: memory.New(), err: errBoom}
+	l.RegisterHandler(slog.InfoLevel, f)
+
+	l.Fatal("boom")
+
+	assert.Equal(t, 1, len(reports))
+	assert.Equal(t, f, reports[0].handler)
+	assert.Nil(t, reports[0].entry)
+	assert.Equal(t, errBoom, reports[0].err)
+}
@@ -0,0 +1,51 @@
+package slog
+
+import "os"
+
+// PanicError is the value that Panic panics with. It carries the Entry that
+// was logged so that recover handlers can log it, including its fields,
+// structurally.
+type PanicError struct {
+	Entry *Entry
+}
+
+// Error implements error, returning the message.
+func (p *PanicError) Error() string {
+	return p.Entry.Message
+}
+
+// SetExitFunc sets the function Fatal calls to exit. It defaults to os.Exit.
+// Passing nil restores the default. It should be called before the Logger is
+// used.
+func (l *Logger) SetExitFunc(fn func(code int)) *Logger {
+	l.exitFunc = fn
+	return l
+}
+
+// SetPanicFunc sets the function Panic calls to panic. It is called with a
+// *PanicError and defaults to the builtin panic. Passing nil restores the
+// default. It should be called before the Logger is used.
+func (l *Logger) SetPanicFunc(fn func(v interface{})) *Logger {
+	l.panicFunc = fn
+	return l
+}
+
+func (l *Logger) exit(code int) {
+	if l.exitFunc != nil {
+		l.exitFunc(code)
+		return
+	}
+
+	os.Exit(code)
+}
+
+func (l *Logger) panic(e *Entry) {
+	v := &PanicError{Entry: e}
+
+	if l.panicFunc != nil {
+		l.panicFunc(v)
+		return
+	}
+
+	panic(v)
+}
@@ -0,0 +1,53 @@
+package slog_test
+
+import (
+	"testing"
+
+	"github.com/joshuarubin/slog"
+	"github.com/joshuarubin/slog/handlers/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLogger_SetExitFunc(t *testing.T) {
+	f := &flusher{Handler: memory.New()}
+
+	code := -1
+	l := slog.New().SetExitFunc(func(c int) { code = c })
+	l.RegisterHandler(slog.InfoLevel, f)
+
+	l.WithField("file", "sloth.png").Fatal("boom")
+
+	assert.Equal(t, 1, code)
+	assert.Equal(t, 1, f.flushed)
+	assert.Equal(t, 1, len(f.Entries))
+	assert.Equal(t, slog.FatalLevel, f.Entries[0].Level)
+}
+
+func TestLogger_Panic(t *testing.T) {
+	l := slog.New()
+
+	defer func() {
+		p, ok := recover().(*slog.PanicError)
+		if assert.True(t, ok) {
+			assert.Equal(t, "boom", p.Error())
+			assert.Equal(t, slog.PanicLevel, p.Entry.Level)
+			assert.Equal(t, slog.Fields{"file": "sloth.png"}, p.Entry.Fields)
+		}
+	}()
+
+	l.WithField("file", "sloth.png").Panic("boom")
+}
+
+func TestLogger_SetPanicFunc(t *testing.T) {
+	h := memory.New()
+
+	var v interface{}
+	l := slog.New().SetPanicFunc(func(p interface{}) { v = p })
+	l.RegisterHandler(slog.InfoLevel, h)
+
+	l.Panic("boom")
+
+	if assert.IsType(t, &slog.PanicError{}, v) {
+		assert.Equal(t, h.Entries[0], v.(*slog.PanicError).Entry)
+	}
+}
@@ -0,0 +1,10 @@
+package slog
+
+import "time"
+
+// SetErrorClock sets the function used to time the intervals of the error
+// limit, so tests don't have to wait for them to end.
+func (l *Logger) SetErrorClock(now func() time.Time) *Logger {
+	l.errorNow = now
+	return l
+}
@@ -0,0 +1,252 @@
+package slog
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// FieldType identifies how the value of a Field is stored.
+type FieldType uint8
+
+// Field types.
+const (
+	AnyType FieldType = iota
+	StringType
+	IntType
+	UintType
+	FloatType
+	BoolType
+	DurationType
+	TimeType
+	ErrorType
+)
+
+// Field is a key and a typed value. Fields created with the typed
+// constructors, such as String and Int, store their value without boxing it in
+// an interface, so handlers can encode them by switching on Type rather than
+// using reflection.
+type Field struct {
+	Key       string
+	Type      FieldType
+	Integer   int64
+	String    string
+	Interface interface{}
+}
+
+// String returns a Field with a string value.
+func String(key, value string) Field {
+	return Field{Key: key, Type: StringType, String: value}
+}
+
+// Int returns a Field with an int value.
+func Int(key string, value int) Field {
+	return Field{Key: key, Type: IntType, Integer: int64(value)}
+}
+
+// Int64 returns a Field with an int64 value.
+func Int64(key string, value int64) Field {
+	return Field{Key: key, Type: IntType, Integer: value}
+}
+
+// Uint64 returns a Field with a uint64 value.
+func Uint64(key string, value uint64) Field {
+	return Field{Key: key, Type: UintType, Integer: int64(value)}
+}
+
+// Float64 returns a Field with a float64 value.
+func Float64(key string, value float64) Field {
+	return Field{Key: key, Type: FloatType, Integer: int64(math.Float64bits(value))}
+}
+
+// Bool returns a Field with a bool value.
+func Bool(key string, value bool) Field {
+	var i int64
+	if value {
+		i = 1
+	}
+
+	return Field{Key: key, Type: BoolType, Integer: i}
+}
+
+// Duration returns a Field with a time.Duration value.
+func Duration(key string, value time.Duration) Field {
+	return Field{Key: key, Type: DurationType, Integer: int64(value)}
+}
+
+// Time returns a Field with a time.Time value. Times that can't be represented
+// as nanoseconds since the Unix epoch, including the zero time, are stored as
+// with Any.
+func Time(key string, value time.Time) Field {
+	if value.Before(minTime) || value.After(maxTime) {
+		return Any(key, value)
+	}
+
+	return Field{Key: key, Type: TimeType, Integer: value.UnixNano(), Interface: value.Location()}
+}
+
+// the range of times that UnixNano can represent.
+var (
+	minTime = time.Unix(0, math.MinInt64)
+	maxTime = time.Unix(0, math.MaxInt64)
+)
+
+// Err returns a Field with the key "error", the same as WithError.
+func Err(err error) Field {
+	return NamedErr("error", err)
+}
+
+// NamedErr returns a Field with an error value.
+func NamedErr(key string, err error) Field {
+	return Field{Key: key, Type: ErrorType, Interface: err}
+}
+
+// Any returns a Field with an arbitrary value. As with WithField, a LazyValue
+// is resolved only if the entry is logged.
+func Any(key string, value interface{}) Field {
+	return Field{Key: key, Interface: value}
+}
+
+// Value returns the value of the field as an interface{}.
+func (f Field) Value() interface{} {
+	switch f.Type {
+	case StringType:
+		return f.String
+	case IntType:
+		return f.Integer
+	case UintType:
+		return f.Uint64()
+	case FloatType:
+		return f.Float64()
+	case BoolType:
+		return f.Bool()
+	case DurationType:
+		return f.Duration()
+	case TimeType:
+		return f.Time()
+	}
+
+	return f.Interface
+}
+
+// Uint64 returns the value of a UintType field.
+func (f Field) Uint64() uint64 {
+	return uint64(f.Integer)
+}
+
+// Float64 returns the value of a FloatType field.
+func (f Field) Float64() float64 {
+	return math.Float64frombits(uint64(f.Integer))
+}
+
+// Bool returns the value of a BoolType field.
+func (f Field) Bool() bool {
+	return f.Integer == 1
+}
+
+// Duration returns the value of a DurationType field.
+func (f Field) Duration() time.Duration {
+	return time.Duration(f.Integer)
+}
+
+// Time returns the value of a TimeType field.
+func (f Field) Time() time.Time {
+	t := time.Unix(0, f.Integer)
+	if loc, ok := f.Interface.(*time.Location); ok {
+		t = t.In(loc)
+	}
+
+	return t
+}
+
+// resolve returns the field with a LazyValue replaced by its result.
+func (f Field) resolve() Field {
+	if f.Type != AnyType {
+		return f
+	}
+
+	switch v := f.Interface.(type) {
+	case LazyValue:
+		f.Interface = v()
+	case func() interface{}:
+		f.Interface = v()
+	}
+
+	return f
+}
+
+// isLazy reports whether f holds a LazyValue.
+func (f Field) isLazy() bool {
+	if f.Type != AnyType {
+		return false
+	}
+
+	switch f.Interface.(type) {
+	case LazyValue, func() interface{}:
+		return true
+	}
+
+	return false
+}
+
+// mapFields returns fields in the same order the map based API adds them, by
+// key.
+func mapFields(fields Fields) []Field {
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	list := make([]Field, len(keys))
+	for i, k := range keys {
+		list[i] = Any(k, fields[k])
+	}
+
+	return list
+}
+
+// fieldsMap returns list as a Fields map.
+func fieldsMap(list []Field) Fields {
+	f := make(Fields, len(list))
+	for _, field := range list {
+		f[field.Key] = field.Value()
+	}
+
+	return f
+}
+
+// AllFields returns the fields of a finalized entry in order. It is FieldList,
+// or Fields sorted by key for entries that were built without a FieldList.
+func (e *Entry) AllFields() []Field {
+	if e.FieldList == nil && len(e.Fields) > 0 {
+		return mapFields(e.Fields)
+	}
+
+	return e.FieldList
+}
+
+// Value returns the value of the field named key on a finalized entry.
+func (e *Entry) Value(key string) (interface{}, bool) {
+	list := e.AllFields()
+	for i := len(list) - 1; i >= 0; i-- {
+		if list[i].Key == key {
+			return list[i].Value(), true
+		}
+	}
+
+	return nil, false
+}
+
+// WithFieldList returns a copy of the finalized entry e with its fields
+// replaced by list, and Fields rebuilt from it. Handlers and middleware that
+// change the fields of an entry should use it rather than modifying e, which
+// is shared with other handlers.
+func (e *Entry) WithFieldList(list []Field) *Entry {
+	c := *e
+	c.FieldList = list
+	c.Fields = fieldsMap(list)
+
+	return &c
+}
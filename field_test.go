@@ -0,0 +1,123 @@
+package slog_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/joshuarubin/slog"
+	"github.com/joshuarubin/slog/handlers/discard"
+	"github.com/joshuarubin/slog/handlers/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestField_Value(t *testing.T) {
+	now := time.Date(2020, 1, 2, 3, 4, 5, 6, time.UTC)
+	err := errors.New("boom")
+
+	fields := []struct {
+		field slog.Field
+		typ   slog.FieldType
+		value interface{}
+	}{
+		{slog.String("s", "hello"), slog.StringType, "hello"},
+		{slog.Int("i", -1), slog.IntType, int64(-1)},
+		{slog.Int64("i64", 1<<40), slog.IntType, int64(1 << 40)},
+		{slog.Uint64("u", 1<<63+1), slog.UintType, uint64(1<<63 + 1)},
+		{slog.Float64("f", 1.5), slog.FloatType, 1.5},
+		{slog.Bool("b", true), slog.BoolType, true},
+		{slog.Bool("b", false), slog.BoolType, false},
+		{slog.Duration("d", time.Second), slog.DurationType, time.Second},
+		{slog.Time("t", now), slog.TimeType, now},
+		{slog.Time("t", time.Time{}), slog.AnyType, time.Time{}},
+		{slog.Err(err), slog.ErrorType, err},
+		{slog.Any("a", []int{1}), slog.AnyType, []int{1}},
+	}
+
+	for _, f := range fields {
+		assert.Equal(t, f.typ, f.field.Type, f.field.Key)
+		assert.Equal(t, f.value, f.field.Value(), f.field.Key)
+	}
+
+	assert.Equal(t, "error", slog.Err(err).Key)
+}
+
+func TestLogger_With(t *testing.T) {
+	h := memory.New()
+
+	l := slog.New()
+	l.RegisterHandler(slog.InfoLevel, h)
+
+	ctx := l.With(slog.String("user", "tobi"), slog.Int("id", 1))
+	ctx.Debug("skipped")
+	ctx.With(slog.Duration("took", time.Second)).Info("logged in")
+
+	assert.Equal(t, 1, len(h.Entries))
+
+	e := h.Entries[0]
+	assert.Equal(t, slog.Fields{"user": "tobi", "id": int64(1), "took": time.Second}, e.Fields)
+	assert.Equal(t, []slog.Field{
+		slog.String("user", "tobi"),
+		slog.Int("id", 1),
+		slog.Duration("took", time.Second),
+	}, e.FieldList)
+
+	v, ok := e.Value("id")
+	assert.True(t, ok)
+	assert.Equal(t, int64(1), v)
+}
+
+func TestLogger_With_mixed(t *testing.T) {
+	h := memory.New()
+
+	l := slog.New()
+	l.RegisterHandler(slog.InfoLevel, h)
+
+	l.With(slog.String("user", "tobi")).
+		WithFields(slog.Fields{"file": "sloth.png", "size": 1 << 20}).
+		With(slog.String("user", "loki"), slog.Any("lazy", slog.LazyValue(func() interface{} {
+			return "resolved"
+		}))).
+		Info("upload")
+
+	e := h.Entries[0]
+	assert.Equal(t, slog.Fields{
+		"user": "loki",
+		"file": "sloth.png",
+		"size": 1 << 20,
+		"lazy": "resolved",
+	}, e.Fields)
+	assert.Equal(t, []slog.Field{
+		slog.String("user", "loki"),
+		slog.Any("file", "sloth.png"),
+		slog.Any("size", 1<<20),
+		slog.Any("lazy", "resolved"),
+	}, e.FieldList)
+}
+
+func TestEntry_WithFieldList(t *testing.T) {
+	e := &slog.Entry{Fields: slog.Fields{"b": 2, "a": 1}}
+	assert.Equal(t, []slog.Field{slog.Any("a", 1), slog.Any("b", 2)}, e.AllFields())
+
+	c := e.WithFieldList([]slog.Field{slog.Int("c", 3)})
+	assert.Equal(t, slog.Fields{"c": int64(3)}, c.Fields)
+	assert.Equal(t, slog.Fields{"b": 2, "a": 1}, e.Fields)
+
+	_, ok := c.Value("a")
+	assert.False(t, ok)
+}
+
+func BenchmarkLogger_medium_typed(b *testing.B) {
+	l := slog.New()
+	l.RegisterHandler(slog.InfoLevel, discard.New())
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		l.With(
+			slog.String("file", "sloth.png"),
+			slog.String("type", "image/png"),
+			slog.Int("size", 1<<20),
+		).Info("upload")
+	}
+}
@@ -0,0 +1,72 @@
+package slog
+
+import (
+	"flag"
+	"fmt"
+	"os"
+)
+
+// assert interface compliance.
+var (
+	_ flag.Value = (*Level)(nil)
+	_ flag.Value = (*LevelVar)(nil)
+)
+
+// Type returns the name of the flag's type in help text, as required by the
+// github.com/spf13/pflag Value interface.
+func (l *Level) Type() string {
+	return "level"
+}
+
+// Type returns the name of the flag's type in help text, as required by the
+// github.com/spf13/pflag Value interface.
+func (v *LevelVar) Type() string {
+	return "level"
+}
+
+// LevelFlagVar defines a Level flag on fs, or flag.CommandLine if fs is nil,
+// with the specified name, default value and usage string. The usage string is
+// followed by the list of valid levels. The argument p points to a Level
+// variable in which to store the value of the flag. Invalid values are
+// rejected when the flags are parsed.
+func LevelFlagVar(fs *flag.FlagSet, p *Level, name string, value Level, usage string) {
+	if fs == nil {
+		fs = flag.CommandLine
+	}
+
+	*p = value
+	fs.Var(p, name, fmt.Sprintf("%s (one of: %s)", usage, levelNameList()))
+}
+
+// LevelFlag is like LevelFlagVar, but returns the address of a new Level
+// variable that stores the value of the flag.
+func LevelFlag(fs *flag.FlagSet, name string, value Level, usage string) *Level {
+	p := new(Level)
+	LevelFlagVar(fs, p, name, value, usage)
+	return p
+}
+
+// LevelFromEnv returns the level in the environment variable named by key, or
+// defaultLevel if it is unset or empty. It returns an error if the variable is
+// not a valid level. The result can be used as the default value of a level
+// flag so that the flag takes precedence over the environment:
+//
+//	level, err := slog.LevelFromEnv("LOG_LEVEL", slog.InfoLevel)
+//	if err != nil {
+//		// handle error
+//	}
+//
+//	slog.LevelFlagVar(nil, &level, "log-level", level, "log level, also set by $LOG_LEVEL")
+func LevelFromEnv(key string, defaultLevel Level) (Level, error) {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultLevel, nil
+	}
+
+	level, err := ParseLevelStrict(value)
+	if err != nil {
+		return defaultLevel, fmt.Errorf("%s: %s", key, err)
+	}
+
+	return level, nil
+}
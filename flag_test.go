@@ -0,0 +1,57 @@
+package slog_test
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"testing"
+
+	"github.com/joshuarubin/slog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLevelFlag(t *testing.T) {
+	var buf bytes.Buffer
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(&buf)
+
+	level := slog.LevelFlag(fs, "log-level", slog.InfoLevel, "log level")
+	assert.Equal(t, slog.InfoLevel, *level)
+
+	assert.NoError(t, fs.Parse([]string{"-log-level", "warning"}))
+	assert.Equal(t, slog.WarnLevel, *level)
+
+	assert.Error(t, fs.Parse([]string{"-log-level", "wat"}))
+	assert.Equal(t, slog.WarnLevel, *level)
+
+	buf.Reset()
+	fs.PrintDefaults()
+	assert.Contains(t, buf.String(), "log level (one of: panic, fatal, error, warn")
+	assert.Contains(t, buf.String(), "(default info)")
+}
+
+func TestLevelFromEnv(t *testing.T) {
+	const key = "SLOG_TEST_LOG_LEVEL"
+	defer os.Unsetenv(key)
+
+	level, err := slog.LevelFromEnv(key, slog.InfoLevel)
+	assert.NoError(t, err)
+	assert.Equal(t, slog.InfoLevel, level)
+
+	os.Setenv(key, "debug")
+	level, err = slog.LevelFromEnv(key, slog.InfoLevel)
+	assert.NoError(t, err)
+	assert.Equal(t, slog.DebugLevel, level)
+
+	os.Setenv(key, "wombat")
+	_, err = slog.LevelFromEnv(key, slog.InfoLevel)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), key)
+}
+
+func TestLevel_Type(t *testing.T) {
+	var level slog.Level
+	assert.Equal(t, "level", level.Type())
+	assert.Equal(t, "level", slog.NewLevelVar(slog.InfoLevel).Type())
+}
@@ -0,0 +1,109 @@
+package slog
+
+// Flusher is implemented by handlers that buffer entries, such as the async
+// handler. Flush should not return until buffered entries have been written.
+type Flusher interface {
+	Flush() error
+}
+
+// Closer is implemented by handlers that hold resources that must be released.
+// Close should flush any buffered entries before releasing them.
+type Closer interface {
+	Close() error
+}
+
+// FlushHandler flushes h if it is a Flusher.
+func FlushHandler(h Handler) error {
+	if f, ok := h.(Flusher); ok {
+		return f.Flush()
+	}
+
+	return nil
+}
+
+// CloseHandler closes h if it is a Closer, or flushes it if it is only a
+// Flusher. Handlers that wrap others use it to forward Close.
+func CloseHandler(h Handler) error {
+	switch h := h.(type) {
+	case Closer:
+		return h.Close()
+	case Flusher:
+		return h.Flush()
+	}
+
+	return nil
+}
+
+// EachHandler calls fn once for every handler in handlers, even if it is
+// listed more than once, and returns the first error. All handlers are
+// called even if some fail.
+func EachHandler(handlers []Handler, fn func(Handler) error) error {
+	var err error
+	seen := map[Handler]bool{}
+
+	for _, h := range handlers {
+		if addSeen(seen, h) {
+			continue
+		}
+
+		if e := fn(h); e != nil && err == nil {
+			err = e
+		}
+	}
+
+	return err
+}
+
+// addSeen reports whether h is already in seen, adding it if not. Handlers
+// that can't be map keys, such as a HandlerFunc, are never seen.
+func addSeen(seen map[Handler]bool, h Handler) (ok bool) {
+	defer func() {
+		if recover() != nil {
+			ok = false
+		}
+	}()
+
+	if seen[h] {
+		return true
+	}
+
+	seen[h] = true
+	return false
+}
+
+// Flush flushes every registered handler that implements Flusher. All handlers
+// are flushed even if some fail, and the first error is returned.
+func (l *Logger) Flush() error {
+	return l.each(FlushHandler)
+}
+
+// Close closes every registered handler that implements Closer, and flushes
+// those that only implement Flusher. All handlers are closed even if some fail,
+// and the first error is returned. The handlers remain registered.
+func (l *Logger) Close() error {
+	return l.each(CloseHandler)
+}
+
+// each calls fn once for every registered handler, as with EachHandler.
+func (l *Logger) each(fn func(Handler) error) error {
+	regs := l.registrations()
+	handlers := make([]Handler, len(regs))
+
+	for i, r := range regs {
+		handlers[i] = r.handler
+	}
+
+	return EachHandler(handlers, fn)
+}
+
+// flush is used before exiting or panicking so that buffered handlers don't
+// lose the entry that caused it. Errors are passed to the ErrorHandler.
+func (l *Logger) flush() {
+	_ = l.each(func(h Handler) error {
+		if err := FlushHandler(h); err != nil {
+			l.reportError(h, nil, err)
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,89 @@
+package slog_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/joshuarubin/slog"
+	"github.com/joshuarubin/slog/handlers/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+type flusher struct {
+	*memory.Handler
+	flushed int
+	err     error
+}
+
+func (f *flusher) Flush() error {
+	f.flushed++
+	return f.err
+}
+
+type closer struct {
+	flusher
+	closed int
+}
+
+func (c *closer) Close() error {
+	c.closed++
+	return c.err
+}
+
+func TestLogger_Flush(t *testing.T) {
+	f := &flusher{Handler: memory.New()}
+	c := &closer{flusher: flusher{Handler: memory.New(), err: errors.New("boom")}}
+
+	l := slog.New()
+	l.RegisterHandler(slog.InfoLevel, f)
+	l.RegisterHandler(slog.DebugLevel, f)
+	l.RegisterHandler(slog.InfoLevel, c)
+	l.RegisterHandler(slog.InfoLevel, memory.New())
+	l.RegisterHandler(slog.InfoLevel, slog.HandlerFunc(func(*slog.Entry) error { return nil }))
+
+	assert.EqualError(t, l.Flush(), "boom")
+	assert.Equal(t, 1, f.flushed)
+	assert.Equal(t, 1, c.flushed)
+	assert.Equal(t, 0, c.closed)
+
+	assert.EqualError(t, l.Close(), "boom")
+	assert.Equal(t, 2, f.flushed)
+	assert.Equal(t, 1, c.flushed)
+	assert.Equal(t, 1, c.closed)
+}
+
+func TestLogger_Panic_flush(t *testing.T) {
+	f := &flusher{Handler: memory.New()}
+
+	l := slog.New()
+	l.RegisterHandler(slog.InfoLevel, f)
+
+	assert.Panics(t, func() {
+		l.Panic("boom")
+	})
+
+	assert.Equal(t, 1, len(f.Entries))
+	assert.Equal(t, 1, f.flushed)
+}
+
+// uncomparable is a Handler that panics if used as a map key.
+type uncomparable struct {
+	flushed []int
+}
+
+func (uncomparable) HandleLog(*slog.Entry) error { return nil }
+
+func TestEachHandler(t *testing.T) {
+	f := &flusher{Handler: memory.New()}
+	u := uncomparable{}
+
+	var called int
+	err := slog.EachHandler([]slog.Handler{f, u, f, u}, func(h slog.Handler) error {
+		called++
+		return slog.FlushHandler(h)
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, called)
+	assert.Equal(t, 1, f.flushed)
+}
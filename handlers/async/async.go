@@ -0,0 +1,255 @@
+// Package async implements a handler that passes entries to another handler on
+// a background goroutine so that slow writers don't block the goroutines that
+// are logging.
+package async
+
+import (
+	"errors"
+	"log"
+	"os"
+	"sync"
+	"sync/atomic"
+
+	"github.com/joshuarubin/slog"
+)
+
+// DefaultSize is the queue size used when Config.Size is not set.
+const DefaultSize = 1024
+
+// DefaultSampleRate is the sample rate used when Config.SampleRate is not set.
+const DefaultSampleRate = 10
+
+// ErrClosed is returned when logging to a Handler that has been closed.
+var ErrClosed = errors.New("async: handler is closed")
+
+// errorLog reports errors from the wrapped handler for entries without a
+// Logger. It writes to stderr directly, as the standard logger may be
+// redirected to a Logger that uses this handler.
+var errorLog = log.New(os.Stderr, "", log.LstdFlags)
+
+// Policy determines what happens to entries logged while the queue is full.
+type Policy int
+
+// Overflow policies.
+const (
+	// Block waits until there is room in the queue.
+	Block Policy = iota
+
+	// DropNewest discards the entry being logged.
+	DropNewest
+
+	// DropOldest discards the oldest entry in the queue to make room.
+	DropOldest
+
+	// Sample waits for room for one of every Config.SampleRate entries and
+	// discards the rest.
+	Sample
+)
+
+// Config for a Handler.
+type Config struct {
+	Size       int
+	Policy     Policy
+	SampleRate int
+
+	// ErrorHandler is called with the Handler, the entry and the error when
+	// the wrapped handler fails to log an entry. By default the error is
+	// passed to the ReportError method of the entry's Logger, so it is handled
+	// as if the Handler had returned it.
+	ErrorHandler slog.ErrorHandler
+}
+
+// assert interface compliance.
+var (
+	_ slog.Flusher = (*Handler)(nil)
+	_ slog.Closer  = (*Handler)(nil)
+)
+
+// Handler implementation.
+type Handler struct {
+	handler    slog.Handler
+	policy     Policy
+	sampleRate uint64
+	onError    slog.ErrorHandler
+	queue      chan item
+	done       chan struct{}
+
+	mu     sync.RWMutex // held for writing to close the queue
+	closed bool
+
+	dropped  uint64
+	overflow uint64
+
+	// sendMu is held while queueing an entry, so entries are queued in the
+	// order of their sequence numbers.
+	sendMu sync.Mutex
+	queued uint64 // sequence number of the last queued entry, written with sendMu held
+
+	cond    *sync.Cond
+	handled uint64 // sequence number of the last entry handled, protected by cond.L
+}
+
+// item is a queued entry and its sequence number.
+type item struct {
+	entry *slog.Entry
+	seq   uint64
+}
+
+// New handler that queues entries for h.
+func New(h slog.Handler, c Config) *Handler {
+	if c.Size <= 0 {
+		c.Size = DefaultSize
+	}
+
+	if c.SampleRate <= 0 {
+		c.SampleRate = DefaultSampleRate
+	}
+
+	ret := &Handler{
+		handler:    h,
+		policy:     c.Policy,
+		sampleRate: uint64(c.SampleRate),
+		onError:    c.ErrorHandler,
+		queue:      make(chan item, c.Size),
+		done:       make(chan struct{}),
+		cond:       sync.NewCond(&sync.Mutex{}),
+	}
+
+	go ret.run()
+
+	return ret
+}
+
+func (h *Handler) run() {
+	defer close(h.done)
+
+	for it := range h.queue {
+		if err := h.handler.HandleLog(it.entry); err != nil {
+			h.reportError(it.entry, err)
+		}
+
+		h.markHandled(it.seq)
+	}
+}
+
+func (h *Handler) reportError(e *slog.Entry, err error) {
+	switch {
+	case h.onError != nil:
+		h.onError(h, e, err)
+	case e.Logger != nil:
+		e.Logger.ReportError(h, e, err)
+	default:
+		errorLog.Printf("error logging: %s", err)
+	}
+}
+
+func (h *Handler) markHandled(seq uint64) {
+	h.cond.L.Lock()
+	h.handled = seq
+	h.cond.L.Unlock()
+	h.cond.Broadcast()
+}
+
+// HandleLog implements slog.Handler.
+func (h *Handler) HandleLog(e *slog.Entry) error {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	if h.closed {
+		return ErrClosed
+	}
+
+	if !h.enqueue(e) {
+		atomic.AddUint64(&h.dropped, 1)
+	}
+
+	return nil
+}
+
+// enqueue reports whether e was queued.
+func (h *Handler) enqueue(e *slog.Entry) bool {
+	h.sendMu.Lock()
+	defer h.sendMu.Unlock()
+
+	it := item{entry: e, seq: h.queued + 1}
+
+	select {
+	case h.queue <- it:
+		atomic.StoreUint64(&h.queued, it.seq)
+		return true
+	default:
+	}
+
+	switch h.policy {
+	case DropNewest:
+		return false
+	case DropOldest:
+		for {
+			select {
+			case h.queue <- it:
+				atomic.StoreUint64(&h.queued, it.seq)
+				return true
+			default:
+			}
+
+			// the discarded entry is never marked handled, Flush waits for
+			// the entry that replaced it instead
+			select {
+			case <-h.queue:
+				atomic.AddUint64(&h.dropped, 1)
+			default:
+			}
+		}
+	case Sample:
+		if atomic.AddUint64(&h.overflow, 1)%h.sampleRate != 0 {
+			return false
+		}
+	}
+
+	h.queue <- it
+	atomic.StoreUint64(&h.queued, it.seq)
+	return true
+}
+
+// Dropped returns the number of entries that have been discarded because the
+// queue was full.
+func (h *Handler) Dropped() uint64 {
+	return atomic.LoadUint64(&h.dropped)
+}
+
+// Flush waits until all of the entries queued before it was called have been
+// passed to the underlying handler, and then flushes it if it is a
+// slog.Flusher.
+func (h *Handler) Flush() error {
+	target := atomic.LoadUint64(&h.queued)
+
+	h.cond.L.Lock()
+	for h.handled < target {
+		h.cond.Wait()
+	}
+	h.cond.L.Unlock()
+
+	return slog.FlushHandler(h.handler)
+}
+
+// Close stops accepting new entries and waits until all of the queued entries
+// have been passed to the underlying handler, and then closes it if it is a
+// slog.Closer, or flushes it if it is a slog.Flusher. It is safe to call more
+// than once, but only the first call closes the underlying handler.
+func (h *Handler) Close() error {
+	h.mu.Lock()
+	closing := !h.closed
+	if closing {
+		h.closed = true
+		close(h.queue)
+	}
+	h.mu.Unlock()
+
+	<-h.done
+
+	if !closing {
+		return nil
+	}
+
+	return slog.CloseHandler(h.handler)
+}
@@ -0,0 +1,206 @@
+package async_test
+
+import (
+	"errors"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/joshuarubin/slog"
+	"github.com/joshuarubin/slog/handlers/async"
+	"github.com/joshuarubin/slog/handlers/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+// gate blocks the first entry it handles until it is released.
+type gate struct {
+	*memory.Handler
+	started chan struct{}
+	release chan struct{}
+}
+
+func newGate() *gate {
+	return &gate{
+		Handler: memory.New(),
+		started: make(chan struct{}),
+		release: make(chan struct{}),
+	}
+}
+
+func (g *gate) HandleLog(e *slog.Entry) error {
+	select {
+	case <-g.started:
+	default:
+		close(g.started)
+		<-g.release
+	}
+
+	return g.Handler.HandleLog(e)
+}
+
+func messages(h *memory.Handler) []string {
+	var ret []string
+	for _, e := range h.Entries {
+		ret = append(ret, e.Message)
+	}
+	return ret
+}
+
+func logN(l *slog.Logger, from, to int) {
+	for i := from; i <= to; i++ {
+		l.Info(strconv.Itoa(i))
+	}
+}
+
+func TestHandler_policies(t *testing.T) {
+	tests := []struct {
+		Policy  async.Policy
+		Expect  []string
+		Dropped uint64
+	}{
+		{async.DropNewest, []string{"1", "2"}, 2},
+		{async.DropOldest, []string{"1", "4"}, 2},
+		{async.Sample, []string{"1", "2", "4"}, 1},
+	}
+
+	for _, test := range tests {
+		g := newGate()
+		h := async.New(g, async.Config{Size: 1, Policy: test.Policy, SampleRate: 2})
+
+		l := slog.New()
+		l.RegisterHandler(slog.InfoLevel, h)
+
+		logN(l, 1, 1)
+		<-g.started
+		logN(l, 2, 3)
+
+		logged := make(chan struct{})
+		go func() {
+			logN(l, 4, 4)
+			close(logged)
+		}()
+
+		// the drop policies never block, so 4 is dropped or queued before the
+		// first entry is released. Sample blocks to queue 4, which it keeps
+		// whether or not the queue has room by then.
+		if test.Policy != async.Sample {
+			<-logged
+		}
+
+		close(g.release)
+		<-logged
+
+		assert.NoError(t, h.Close())
+		assert.Equal(t, test.Expect, messages(g.Handler), test.Policy)
+		assert.Equal(t, test.Dropped, h.Dropped(), test.Policy)
+	}
+}
+
+func TestHandler_Block(t *testing.T) {
+	m := memory.New()
+	h := async.New(m, async.Config{Size: 2})
+
+	l := slog.New()
+	l.RegisterHandler(slog.InfoLevel, h)
+
+	logN(l, 1, 100)
+
+	assert.NoError(t, h.Flush())
+	assert.Equal(t, 100, len(m.Entries))
+	assert.Equal(t, uint64(0), h.Dropped())
+}
+
+// set records the messages of the entries it handles.
+type set struct {
+	mu   sync.Mutex
+	seen map[string]bool
+}
+
+func (s *set) HandleLog(e *slog.Entry) error {
+	s.mu.Lock()
+	s.seen[e.Message] = true
+	s.mu.Unlock()
+	return nil
+}
+
+func (s *set) has(msg string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.seen[msg]
+}
+
+func TestHandler_Flush_concurrent(t *testing.T) {
+	s := &set{seen: map[string]bool{}}
+	h := async.New(s, async.Config{Size: 1})
+
+	l := slog.New()
+	l.RegisterHandler(slog.InfoLevel, h)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 64; i++ {
+		wg.Add(1)
+		go func(msg string) {
+			defer wg.Done()
+
+			for j := 0; j < 20; j++ {
+				msg := msg + "." + strconv.Itoa(j)
+				l.Info(msg)
+				assert.NoError(t, h.Flush())
+				assert.True(t, s.has(msg), msg)
+			}
+		}(strconv.Itoa(i))
+	}
+
+	wg.Wait()
+}
+
+func TestHandler_Close(t *testing.T) {
+	g := newGate()
+	h := async.New(g, async.Config{})
+
+	l := slog.New()
+	l.RegisterHandler(slog.InfoLevel, h)
+
+	logN(l, 1, 10)
+	close(g.release)
+
+	assert.NoError(t, h.Close())
+	assert.NoError(t, h.Close())
+	assert.Equal(t, 10, len(g.Entries))
+	assert.Equal(t, async.ErrClosed, h.HandleLog(&slog.Entry{}))
+}
+
+func TestHandler_errors(t *testing.T) {
+	errBoom := errors.New("boom")
+	failing := slog.HandlerFunc(func(*slog.Entry) error { return errBoom })
+
+	var reports []error
+	l := slog.New().SetErrorHandler(func(h slog.Handler, e *slog.Entry, err error) {
+		assert.IsType(t, (*async.Handler)(nil), h)
+		assert.Equal(t, "1", e.Message)
+		reports = append(reports, err)
+	})
+
+	h := async.New(failing, async.Config{})
+	r := l.Register(slog.InfoLevel, h)
+
+	logN(l, 1, 1)
+
+	assert.NoError(t, h.Flush())
+	assert.Equal(t, []error{errBoom}, reports)
+	assert.Equal(t, uint64(1), r.Errors())
+
+	var hooked []error
+	h = async.New(failing, async.Config{
+		ErrorHandler: func(_ slog.Handler, _ *slog.Entry, err error) {
+			hooked = append(hooked, err)
+		},
+	})
+	r = l.Register(slog.InfoLevel, h)
+
+	logN(l, 1, 1)
+
+	assert.NoError(t, l.Flush())
+	assert.Equal(t, []error{errBoom}, hooked)
+	assert.Equal(t, uint64(0), r.Errors())
+}
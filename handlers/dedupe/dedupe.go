@@ -0,0 +1,194 @@
+// Package dedupe implements a handler that collapses consecutive identical
+// entries, providing "last message repeated N times" semantics.
+//
+// The first entry of a run of entries with the same level, message and fields
+// is passed on to the wrapped handler, and the rest are held back. When the run
+// ends, because a different entry is logged, the window elapses or the handler
+// is flushed, a summary entry is passed on with the number of entries that
+// were held back as the "repeat" field and the times of the first and last
+// entries of the run as the "first" and "last" fields.
+package dedupe
+
+import (
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/joshuarubin/slog"
+)
+
+// Fields added to summary entries.
+const (
+	RepeatKey = "repeat"
+	FirstKey  = "first"
+	LastKey   = "last"
+)
+
+// Config for a Handler.
+type Config struct {
+	// Window is the longest a run can last, measured from the time of its
+	// first entry. The summary of a run is passed on once its window elapses,
+	// even if no other entry is logged. If it is zero runs are not limited.
+	Window time.Duration
+}
+
+// Handler implementation.
+type Handler struct {
+	handler slog.Handler
+	config  Config
+
+	mu     sync.Mutex
+	first  *slog.Entry
+	last   time.Time
+	repeat int
+	run    uint64 // incremented when a run ends, so stale timers are ignored
+	timer  *time.Timer
+}
+
+// assert interface compliance.
+var (
+	_ slog.Flusher = (*Handler)(nil)
+	_ slog.Closer  = (*Handler)(nil)
+)
+
+// New handler that passes deduplicated entries to h.
+func New(h slog.Handler, c Config) *Handler {
+	return &Handler{
+		handler: h,
+		config:  c,
+	}
+}
+
+// Middleware returns slog.Middleware that wraps handlers with New.
+func Middleware(c Config) slog.Middleware {
+	return func(h slog.Handler) slog.Handler {
+		return New(h, c)
+	}
+}
+
+func same(a, b *slog.Entry) bool {
+	return a.Level == b.Level &&
+		a.Message == b.Message &&
+		reflect.DeepEqual(a.AllFields(), b.AllFields())
+}
+
+// HandleLog implements slog.Handler.
+func (h *Handler) HandleLog(e *slog.Entry) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.first != nil && same(h.first, e) &&
+		(h.config.Window <= 0 || e.Time.Sub(h.first.Time) < h.config.Window) {
+		h.repeat++
+		h.last = e.Time
+
+		if h.repeat == 1 && h.config.Window > 0 {
+			h.arm(h.config.Window - e.Time.Sub(h.first.Time))
+		}
+
+		return nil
+	}
+
+	err := h.end()
+
+	h.first = e
+
+	if herr := h.handler.HandleLog(e); herr != nil {
+		return herr
+	}
+
+	return err
+}
+
+// arm ends the current run after d, if it hasn't ended by then. It must be
+// called with h.mu held.
+func (h *Handler) arm(d time.Duration) {
+	run := h.run
+
+	h.timer = time.AfterFunc(d, func() {
+		h.mu.Lock()
+		if h.run != run {
+			h.mu.Unlock()
+			return
+		}
+
+		first := h.first
+		err := h.end()
+		h.mu.Unlock()
+
+		// there is no caller to return the error to, so it is reported as
+		// if HandleLog had returned it
+		if err != nil && first.Logger != nil {
+			first.Logger.ReportError(h, first, err)
+		}
+	})
+}
+
+// end passes on the summary of the current run, if any entries were held back,
+// and resets it. It must be called with h.mu held.
+func (h *Handler) end() error {
+	if h.first == nil {
+		return nil
+	}
+
+	h.run++
+
+	if h.timer != nil {
+		h.timer.Stop()
+		h.timer = nil
+	}
+
+	first, repeat := h.first, h.repeat
+	h.first, h.repeat = nil, 0
+
+	if repeat == 0 {
+		return nil
+	}
+
+	fields := first.AllFields()
+	list := make([]slog.Field, len(fields), len(fields)+3)
+	copy(list, fields)
+
+	for _, f := range []slog.Field{
+		slog.Any(RepeatKey, repeat),
+		slog.Time(FirstKey, first.Time),
+		slog.Time(LastKey, h.last),
+	} {
+		if _, ok := first.Value(f.Key); !ok {
+			list = append(list, f)
+		}
+	}
+
+	c := first.WithFieldList(list)
+	c.Time = h.last
+
+	return h.handler.HandleLog(c)
+}
+
+// Flush ends the current run, passing on its summary, and flushes the wrapped
+// handler if it is a slog.Flusher.
+func (h *Handler) Flush() error {
+	h.mu.Lock()
+	err := h.end()
+	h.mu.Unlock()
+
+	if err != nil {
+		return err
+	}
+
+	return slog.FlushHandler(h.handler)
+}
+
+// Close ends the current run, passing on its summary, and closes the wrapped
+// handler if it is a slog.Closer, or flushes it if it is a slog.Flusher.
+func (h *Handler) Close() error {
+	h.mu.Lock()
+	err := h.end()
+	h.mu.Unlock()
+
+	if err != nil {
+		return err
+	}
+
+	return slog.CloseHandler(h.handler)
+}
@@ -0,0 +1,111 @@
+package dedupe_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/joshuarubin/slog"
+	"github.com/joshuarubin/slog/handlers/dedupe"
+	"github.com/joshuarubin/slog/handlers/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+var start = time.Date(2019, 2, 3, 4, 5, 6, 0, time.UTC)
+
+func entry(level slog.Level, msg string, sec int, fields slog.Fields) *slog.Entry {
+	return &slog.Entry{
+		Level:   level,
+		Message: msg,
+		Time:    start.Add(time.Duration(sec) * time.Second),
+		Fields:  fields,
+	}
+}
+
+func TestHandler(t *testing.T) {
+	m := memory.New()
+	h := dedupe.New(m, dedupe.Config{Window: time.Minute})
+
+	f := slog.Fields{"user": "tobi"}
+
+	for i := 0; i < 4; i++ {
+		assert.NoError(t, h.HandleLog(entry(slog.ErrorLevel, "boom", i, f)))
+	}
+
+	assert.Equal(t, 1, len(m.Entries))
+
+	assert.NoError(t, h.HandleLog(entry(slog.ErrorLevel, "boom", 4, slog.Fields{"user": "loki"})))
+
+	assert.Equal(t, 3, len(m.Entries))
+	assert.Equal(t, slog.Fields{
+		"user":           "tobi",
+		dedupe.RepeatKey: 3,
+		dedupe.FirstKey:  start,
+		dedupe.LastKey:   start.Add(3 * time.Second),
+	}, m.Entries[1].Fields)
+	assert.Equal(t, "boom", m.Entries[1].Message)
+
+	for _, f := range m.Entries[1].FieldList {
+		if f.Key == dedupe.FirstKey || f.Key == dedupe.LastKey {
+			assert.Equal(t, slog.TimeType, f.Type, f.Key)
+		}
+	}
+	assert.Equal(t, "loki", m.Entries[2].Fields["user"])
+
+	// the window elapses, a new run is started
+	assert.NoError(t, h.HandleLog(entry(slog.ErrorLevel, "boom", 30, slog.Fields{"user": "loki"})))
+	assert.NoError(t, h.HandleLog(entry(slog.ErrorLevel, "boom", 65, slog.Fields{"user": "loki"})))
+
+	assert.Equal(t, 5, len(m.Entries))
+	assert.Equal(t, 1, m.Entries[3].Fields[dedupe.RepeatKey])
+	assert.Nil(t, m.Entries[4].Fields[dedupe.RepeatKey])
+
+	// unrepeated runs have no summary
+	assert.NoError(t, h.HandleLog(entry(slog.WarnLevel, "boom", 66, nil)))
+	assert.Equal(t, 6, len(m.Entries))
+}
+
+func TestHandler_Flush(t *testing.T) {
+	m := memory.New()
+
+	l := slog.New()
+	l.RegisterHandler(slog.InfoLevel, dedupe.Middleware(dedupe.Config{})(m))
+
+	for i := 0; i < 3; i++ {
+		l.Info("repeated")
+	}
+
+	assert.Equal(t, 1, len(m.Entries))
+	assert.NoError(t, l.Flush())
+	assert.Equal(t, 2, len(m.Entries))
+	assert.Equal(t, 2, m.Entries[1].Fields[dedupe.RepeatKey])
+
+	l.Info("repeated")
+	assert.NoError(t, l.Close())
+	assert.Equal(t, 3, len(m.Entries))
+}
+
+func TestHandler_Window(t *testing.T) {
+	summaries := make(chan *slog.Entry, 1)
+	notify := slog.HandlerFunc(func(e *slog.Entry) error {
+		if _, ok := e.Value(dedupe.RepeatKey); ok {
+			summaries <- e
+		}
+		return nil
+	})
+
+	l := slog.New()
+	l.RegisterHandler(slog.InfoLevel, dedupe.New(notify, dedupe.Config{Window: 10 * time.Millisecond}))
+
+	// a run followed by silence is summarized once its window elapses
+	for i := 0; i < 3; i++ {
+		l.Error("boom")
+	}
+
+	select {
+	case e := <-summaries:
+		v, _ := e.Value(dedupe.RepeatKey)
+		assert.Equal(t, 2, v)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no summary after the window elapsed")
+	}
+}
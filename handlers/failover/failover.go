@@ -0,0 +1,198 @@
+// Package failover implements a handler that passes entries to a primary
+// handler and, when it fails, to a secondary handler until it recovers.
+//
+// An entry that the primary handler returns an error for is passed to the
+// secondary handler instead. Once the primary has failed Threshold times in a
+// row the handler fails over, and entries go straight to the secondary. While
+// failed over, one entry every ProbeInterval is tried on the primary first; if
+// it succeeds the handler recovers and entries go to the primary again.
+//
+// Failing over and recovering are logged as entries with the FailoverMessage
+// and RecoverMessage messages, passed to the secondary and primary handlers
+// respectively.
+package failover
+
+import (
+	"sync"
+	"time"
+
+	"github.com/joshuarubin/slog"
+)
+
+// Messages of the entries logged when failing over and recovering.
+const (
+	FailoverMessage = "failover: primary handler failed"
+	RecoverMessage  = "failover: primary handler recovered"
+)
+
+// Fields added to the failover and recover entries.
+const (
+	// ErrorsKey is the number of consecutive errors from the primary handler.
+	ErrorsKey = "errors"
+
+	// DownKey is how long the primary handler was failed over.
+	DownKey = "down"
+
+	// RoutedKey is the number of entries passed to the secondary handler while
+	// failed over.
+	RoutedKey = "routed"
+)
+
+// DefaultProbeInterval is used when Config.ProbeInterval is not set.
+const DefaultProbeInterval = 10 * time.Second
+
+// Config for a Handler.
+type Config struct {
+	// Threshold is the number of consecutive errors from the primary handler
+	// after which the handler fails over. It defaults to 1.
+	Threshold int
+
+	// ProbeInterval is how often, while failed over, an entry is tried on the
+	// primary handler. It defaults to DefaultProbeInterval.
+	ProbeInterval time.Duration
+
+	// Now returns the current time. It defaults to time.Now.
+	Now func() time.Time
+}
+
+// Handler implementation.
+type Handler struct {
+	primary   slog.Handler
+	secondary slog.Handler
+	config    Config
+
+	mu     sync.Mutex
+	errors int
+	failed bool
+	since  time.Time
+	probed time.Time
+	routed int
+}
+
+// assert interface compliance.
+var (
+	_ slog.Flusher = (*Handler)(nil)
+	_ slog.Closer  = (*Handler)(nil)
+)
+
+// New handler that passes entries to primary, or to secondary when primary
+// fails.
+func New(primary, secondary slog.Handler, c Config) *Handler {
+	if c.Threshold < 1 {
+		c.Threshold = 1
+	}
+
+	if c.ProbeInterval <= 0 {
+		c.ProbeInterval = DefaultProbeInterval
+	}
+
+	if c.Now == nil {
+		c.Now = time.Now
+	}
+
+	return &Handler{
+		primary:   primary,
+		secondary: secondary,
+		config:    c,
+	}
+}
+
+// Failed reports whether the handler has failed over to the secondary
+// handler.
+func (h *Handler) Failed() bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.failed
+}
+
+// HandleLog implements slog.Handler. It only returns an error if the entry
+// could not be passed to either handler.
+func (h *Handler) HandleLog(e *slog.Entry) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	now := h.config.Now()
+
+	if h.failed {
+		if now.Sub(h.probed) < h.config.ProbeInterval {
+			h.routed++
+			return h.secondary.HandleLog(e)
+		}
+
+		h.probed = now
+	}
+
+	err := h.primary.HandleLog(e)
+	if err == nil {
+		if h.failed {
+			h.restore(e, now)
+		}
+
+		h.errors = 0
+		return nil
+	}
+
+	h.errors++
+
+	if !h.failed && h.errors >= h.config.Threshold {
+		h.fail(e, now, err)
+	}
+
+	if h.failed {
+		h.routed++
+	}
+
+	return h.secondary.HandleLog(e)
+}
+
+// fail logs the failover entry to the secondary handler. It must be called
+// with h.mu held.
+func (h *Handler) fail(e *slog.Entry, now time.Time, err error) {
+	h.failed = true
+	h.since = now
+	h.probed = now
+	h.routed = 0
+
+	_ = h.secondary.HandleLog(event(e, slog.WarnLevel, now, FailoverMessage,
+		slog.Int(ErrorsKey, h.errors),
+		slog.Err(err),
+	))
+}
+
+// restore logs the recover entry to the primary handler. It must be called
+// with h.mu held.
+func (h *Handler) restore(e *slog.Entry, now time.Time) {
+	h.failed = false
+
+	_ = h.primary.HandleLog(event(e, slog.InfoLevel, now, RecoverMessage,
+		slog.Duration(DownKey, now.Sub(h.since)),
+		slog.Int(RoutedKey, h.routed),
+	))
+}
+
+// event returns an entry for the same Logger and Context as e.
+func event(e *slog.Entry, level slog.Level, now time.Time, msg string, fields ...slog.Field) *slog.Entry {
+	ev := &slog.Entry{
+		Logger:  e.Logger,
+		Context: e.Context,
+		Fields:  slog.Fields{},
+		Level:   level,
+		Time:    now,
+		Message: msg,
+	}
+
+	return ev.WithFieldList(fields)
+}
+
+// Flush implements slog.Flusher, flushing both handlers if they are
+// slog.Flushers. Both are flushed even if one fails, and the first error is
+// returned.
+func (h *Handler) Flush() error {
+	return slog.EachHandler([]slog.Handler{h.primary, h.secondary}, slog.FlushHandler)
+}
+
+// Close implements slog.Closer, closing both handlers if they are
+// slog.Closers, or flushing them if they are slog.Flushers.
+func (h *Handler) Close() error {
+	return slog.EachHandler([]slog.Handler{h.primary, h.secondary}, slog.CloseHandler)
+}
@@ -0,0 +1,108 @@
+package failover_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/joshuarubin/slog"
+	"github.com/joshuarubin/slog/handlers/failover"
+	"github.com/joshuarubin/slog/handlers/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+var errDown = errors.New("down")
+
+// primary fails while down is set.
+type primary struct {
+	*memory.Handler
+	down bool
+}
+
+func (p *primary) HandleLog(e *slog.Entry) error {
+	if p.down {
+		return errDown
+	}
+
+	return p.Handler.HandleLog(e)
+}
+
+func messages(m *memory.Handler) []string {
+	ret := make([]string, len(m.Entries))
+	for i, e := range m.Entries {
+		ret[i] = e.Message
+	}
+
+	return ret
+}
+
+func TestHandler(t *testing.T) {
+	now := time.Unix(0, 0)
+	p := &primary{Handler: memory.New()}
+	s := memory.New()
+
+	h := failover.New(p, s, failover.Config{
+		Threshold:     2,
+		ProbeInterval: time.Minute,
+		Now:           func() time.Time { return now },
+	})
+
+	l := slog.New()
+	l.RegisterHandler(slog.InfoLevel, h)
+
+	l.Info("1")
+
+	p.down = true
+	l.Info("2")
+	assert.False(t, h.Failed())
+
+	l.Info("3")
+	assert.True(t, h.Failed())
+
+	now = now.Add(30 * time.Second)
+	l.Info("4")
+
+	p.down = false
+	l.Info("5")
+
+	now = now.Add(30 * time.Second)
+	l.Info("6")
+	assert.False(t, h.Failed())
+
+	l.Info("7")
+
+	assert.Equal(t, []string{"1", "6", failover.RecoverMessage, "7"}, messages(p.Handler))
+	assert.Equal(t, []string{"2", failover.FailoverMessage, "3", "4", "5"}, messages(s))
+
+	e := s.Entries[1]
+	assert.Equal(t, slog.WarnLevel, e.Level)
+	assert.Equal(t, int64(2), e.Fields[failover.ErrorsKey])
+	assert.Equal(t, errDown, e.Fields["error"])
+
+	e = p.Entries[2]
+	assert.Equal(t, slog.InfoLevel, e.Level)
+	assert.Equal(t, time.Minute, e.Fields[failover.DownKey])
+	assert.Equal(t, int64(3), e.Fields[failover.RoutedKey])
+}
+
+type closer struct {
+	*memory.Handler
+	closed int
+}
+
+func (c *closer) Close() error {
+	c.closed++
+	return nil
+}
+
+func TestHandler_Close(t *testing.T) {
+	p := &closer{Handler: memory.New()}
+	s := &closer{Handler: memory.New()}
+
+	h := failover.New(p, s, failover.Config{})
+	assert.NoError(t, h.Flush())
+	assert.NoError(t, h.Close())
+
+	assert.Equal(t, 1, p.closed)
+	assert.Equal(t, 1, s.closed)
+}
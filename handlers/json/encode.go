@@ -0,0 +1,174 @@
+package json
+
+import (
+	j "encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+	"unicode/utf8"
+
+	"github.com/joshuarubin/slog"
+)
+
+// timeFormat matches the encoding of time.Time by encoding/json.
+const timeFormat = time.RFC3339Nano
+
+// appendField appends the JSON value of f. Stringers and errors are written
+// as strings, and values without a typed encoding fall back to encoding/json.
+func appendField(b []byte, f slog.Field) ([]byte, error) {
+	switch f.Type {
+	case slog.StringType:
+		return appendString(b, f.String), nil
+	case slog.IntType:
+		return strconv.AppendInt(b, f.Integer, 10), nil
+	case slog.UintType:
+		return strconv.AppendUint(b, f.Uint64(), 10), nil
+	case slog.FloatType:
+		return appendFloat(b, f.Float64(), 64), nil
+	case slog.BoolType:
+		return strconv.AppendBool(b, f.Bool()), nil
+	case slog.DurationType:
+		return appendString(b, f.Duration().String()), nil
+	case slog.TimeType:
+		return appendTime(b, f.Time()), nil
+	}
+
+	return appendValue(b, f.Interface)
+}
+
+func appendValue(b []byte, value interface{}) ([]byte, error) {
+	switch v := value.(type) {
+	case nil:
+		return append(b, "null"...), nil
+	case string:
+		return appendString(b, v), nil
+	case bool:
+		return strconv.AppendBool(b, v), nil
+	case int:
+		return strconv.AppendInt(b, int64(v), 10), nil
+	case int64:
+		return strconv.AppendInt(b, v, 10), nil
+	case int32:
+		return strconv.AppendInt(b, int64(v), 10), nil
+	case uint:
+		return strconv.AppendUint(b, uint64(v), 10), nil
+	case uint64:
+		return strconv.AppendUint(b, v, 10), nil
+	case uint32:
+		return strconv.AppendUint(b, uint64(v), 10), nil
+	case float64:
+		return appendFloat(b, v, 64), nil
+	case float32:
+		return appendFloat(b, float64(v), 32), nil
+	case time.Time:
+		return appendTime(b, v), nil
+	case fmt.Stringer:
+		return appendString(b, v.String()), nil
+	case error:
+		return appendString(b, v.Error()), nil
+	}
+
+	return appendMarshal(b, value)
+}
+
+func appendTime(b []byte, t time.Time) []byte {
+	b = append(b, '"')
+	b = t.AppendFormat(b, timeFormat)
+	return append(b, '"')
+}
+
+func appendMarshal(b []byte, value interface{}) ([]byte, error) {
+	data, err := j.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(b, data...), nil
+}
+
+// appendFloat formats f the same as encoding/json. NaN and infinities, which
+// JSON can't represent, are written as strings.
+func appendFloat(b []byte, f float64, bits int) []byte {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return appendString(b, strconv.FormatFloat(f, 'g', -1, bits))
+	}
+
+	abs := math.Abs(f)
+	format := byte('f')
+	if abs != 0 && (bits == 64 && (abs < 1e-6 || abs >= 1e21) ||
+		bits == 32 && (float32(abs) < 1e-6 || float32(abs) >= 1e21)) {
+		format = 'e'
+	}
+
+	b = strconv.AppendFloat(b, f, format, -1, bits)
+
+	if format == 'e' {
+		// clean up e-09 to e-9
+		n := len(b)
+		if n >= 4 && b[n-4] == 'e' && b[n-3] == '-' && b[n-2] == '0' {
+			b[n-2] = b[n-1]
+			b = b[:n-1]
+		}
+	}
+
+	return b
+}
+
+const hex = "0123456789abcdef"
+
+// appendString appends s as a JSON string, escaped the same as encoding/json.
+func appendString(b []byte, s string) []byte {
+	b = append(b, '"')
+	start := 0
+
+	for i := 0; i < len(s); {
+		if c := s[i]; c < utf8.RuneSelf {
+			if c >= 0x20 && c != '"' && c != '\\' && c != '<' && c != '>' && c != '&' {
+				i++
+				continue
+			}
+
+			b = append(b, s[start:i]...)
+
+			switch c {
+			case '"', '\\':
+				b = append(b, '\\', c)
+			case '\n':
+				b = append(b, '\\', 'n')
+			case '\r':
+				b = append(b, '\\', 'r')
+			case '\t':
+				b = append(b, '\\', 't')
+			default:
+				b = append(b, '\\', 'u', '0', '0', hex[c>>4], hex[c&0xf])
+			}
+
+			i++
+			start = i
+			continue
+		}
+
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if r == utf8.RuneError && size == 1 {
+			b = append(b, s[start:i]...)
+			b = append(b, "\ufffd"...)
+			i += size
+			start = i
+			continue
+		}
+
+		if r == '\u2028' || r == '\u2029' {
+			b = append(b, s[start:i]...)
+			b = append(b, '\\', 'u', '2', '0', '2', hex[r&0xf])
+			i += size
+			start = i
+			continue
+		}
+
+		i += size
+	}
+
+	b = append(b, s[start:]...)
+	return append(b, '"')
+}
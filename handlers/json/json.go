@@ -2,12 +2,9 @@
 package json
 
 import (
-	j "encoding/json"
-	"fmt"
 	"io"
 	"os"
 	"sync"
-	"time"
 
 	"github.com/joshuarubin/slog"
 )
@@ -24,48 +21,73 @@ func Logger(level slog.Level) *slog.Logger {
 // Handler implementation.
 type Handler struct {
 	mu  sync.Mutex
-	enc *j.Encoder
+	w   io.Writer
+	buf []byte
 }
 
 // New handler.
 func New(w io.Writer) *Handler {
 	return &Handler{
-		enc: j.NewEncoder(w),
+		w: w,
 	}
 }
 
-type entry struct {
-	Fields  slog.Fields `json:"fields"`
-	Level   slog.Level  `json:"level"`
-	Time    time.Time   `json:"time"`
-	Message string      `json:"msg"`
+// HandleLog implements slog.Handler. Entries are written as a single line
+// object with "fields", "level", "time", "msg" and, when set, "caller" and
+// "stack" keys. Fields are written in order and typed fields are encoded
+// without reflection.
+func (h *Handler) HandleLog(e *slog.Entry) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	b, err := appendEntry(h.buf[:0], e)
+	if err != nil {
+		return err
+	}
+
+	h.buf = b
+	_, err = h.w.Write(b)
+	return err
 }
 
-func newEntry(e *slog.Entry) *entry {
-	ret := &entry{
-		Fields:  slog.Fields{},
-		Level:   e.Level,
-		Time:    e.Time,
-		Message: e.Message,
+func appendEntry(b []byte, e *slog.Entry) ([]byte, error) {
+	var err error
+
+	b = append(b, `{"fields":{`...)
+
+	for i, f := range e.AllFields() {
+		if i > 0 {
+			b = append(b, ',')
+		}
+
+		b = appendString(b, f.Key)
+		b = append(b, ':')
+
+		if b, err = appendField(b, f); err != nil {
+			return nil, err
+		}
 	}
 
-	for key, value := range e.Fields {
-		switch value := value.(type) {
-		case fmt.Stringer:
-			ret.Fields[key] = value.String()
-		case error:
-			ret.Fields[key] = value.Error()
-		default:
-			ret.Fields[key] = value
+	b = append(b, `},"level":`...)
+	b = appendString(b, e.Level.String())
+	b = append(b, `,"time":"`...)
+	b = e.Time.AppendFormat(b, timeFormat)
+	b = append(b, `","msg":`...)
+	b = appendString(b, e.Message)
+
+	if e.Caller != nil {
+		b = append(b, `,"caller":`...)
+		if b, err = appendMarshal(b, e.Caller); err != nil {
+			return nil, err
 		}
 	}
 
-	return ret
-}
+	if len(e.Stack) > 0 {
+		b = append(b, `,"stack":`...)
+		if b, err = appendMarshal(b, e.Stack); err != nil {
+			return nil, err
+		}
+	}
 
-// HandleLog implements slog.Handler.
-func (h *Handler) HandleLog(e *slog.Entry) error {
-	h.mu.Lock()
-	defer h.mu.Unlock()
-	return h.enc.Encode(newEntry(e))
+	return append(b, "}\n"...), nil
 }
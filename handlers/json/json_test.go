@@ -0,0 +1,154 @@
+package json
+
+import (
+	"bytes"
+	j "encoding/json"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/joshuarubin/slog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAppendString(t *testing.T) {
+	for _, s := range []string{
+		"", "hello", `"quoted" \ slash`, "new\nline\ttab\r", "\x00\x1f",
+		"<script>&amp;</script>", "caf\u00e9 \u2028\u2029", "bad \xff utf8",
+	} {
+		expect, err := j.Marshal(s)
+		assert.NoError(t, err)
+		assert.Equal(t, string(expect), string(appendString(nil, s)), s)
+	}
+}
+
+func TestAppendFloat(t *testing.T) {
+	for _, f := range []float64{0, 1, -1.5, 3.0, 1e-7, 1e20, 1e21, 123456789.125, math.SmallestNonzeroFloat64} {
+		expect, err := j.Marshal(f)
+		assert.NoError(t, err)
+		assert.Equal(t, string(expect), string(appendFloat(nil, f, 64)), f)
+	}
+
+	assert.Equal(t, `"NaN"`, string(appendFloat(nil, math.NaN(), 64)))
+	assert.Equal(t, `"+Inf"`, string(appendFloat(nil, math.Inf(1), 64)))
+}
+
+func TestAppendTime(t *testing.T) {
+	for _, tm := range []time.Time{
+		time.Now(),
+		time.Date(2019, 2, 3, 4, 5, 6, 7, time.FixedZone("X", -3600)),
+	} {
+		expect, err := j.Marshal(tm)
+		assert.NoError(t, err)
+
+		b, err := appendField(nil, slog.Time("t", tm))
+		assert.NoError(t, err)
+		assert.Equal(t, string(expect), string(b), tm)
+
+		b, err = appendValue(nil, tm)
+		assert.NoError(t, err)
+		assert.Equal(t, string(expect), string(b), tm)
+	}
+}
+
+func TestHandler(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := slog.New()
+	l.RegisterHandler(slog.InfoLevel, New(&buf))
+
+	l.With(
+		slog.String("user", "tobi"),
+		slog.Int("id", 1),
+		slog.Float64("ratio", 0.5),
+		slog.Bool("admin", false),
+		slog.Duration("took", 1500*time.Millisecond),
+		slog.Err(errors.New("boom")),
+		slog.Any("tags", []string{"a", "b"}),
+	).Info("login")
+
+	var e struct {
+		Fields  map[string]interface{} `json:"fields"`
+		Level   string                 `json:"level"`
+		Time    time.Time              `json:"time"`
+		Message string                 `json:"msg"`
+	}
+
+	assert.NoError(t, j.Unmarshal(buf.Bytes(), &e))
+	assert.Equal(t, map[string]interface{}{
+		"user":  "tobi",
+		"id":    1.0,
+		"ratio": 0.5,
+		"admin": false,
+		"took":  "1.5s",
+		"error": "boom",
+		"tags":  []interface{}{"a", "b"},
+	}, e.Fields)
+	assert.Equal(t, "info", e.Level)
+	assert.Equal(t, "login", e.Message)
+	assert.False(t, e.Time.IsZero())
+	assert.Equal(t, byte('\n'), buf.Bytes()[buf.Len()-1])
+}
+
+func TestHandler_stable(t *testing.T) {
+	e := &slog.Entry{
+		FieldList: []slog.Field{
+			slog.String("user", "tobi"),
+			slog.Any("b", 2),
+			slog.Any("a", 1),
+			slog.Int("id", 1),
+		},
+		Level:   slog.WarnLevel,
+		Time:    time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC),
+		Message: "upload",
+	}
+
+	const expect = `{"fields":{"user":"tobi","b":2,"a":1,"id":1},"level":"warn","time":"2020-01-02T03:04:05Z","msg":"upload"}` + "\n"
+
+	for i := 0; i < 20; i++ {
+		var buf bytes.Buffer
+		assert.NoError(t, New(&buf).HandleLog(e))
+		assert.Equal(t, expect, buf.String())
+	}
+
+	e = &slog.Entry{Fields: slog.Fields{"b": 2, "a": 1, "c": 3}, Level: slog.InfoLevel, Time: e.Time}
+
+	for i := 0; i < 20; i++ {
+		var buf bytes.Buffer
+		assert.NoError(t, New(&buf).HandleLog(e))
+		assert.Equal(t, `{"fields":{"a":1,"b":2,"c":3},"level":"info","time":"2020-01-02T03:04:05Z","msg":""}`+"\n", buf.String())
+	}
+}
+
+func TestHandler_caller(t *testing.T) {
+	e := &slog.Entry{
+		Level:   slog.InfoLevel,
+		Time:    time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC),
+		Message: "upload",
+		Caller:  &slog.Caller{File: "/src/app/main.go", Line: 42, Function: "main.main"},
+	}
+
+	var buf bytes.Buffer
+	assert.NoError(t, New(&buf).HandleLog(e))
+	assert.Equal(t, `{"fields":{},"level":"info","time":"2020-01-02T03:04:05Z","msg":"upload",`+
+		`"caller":{"file":"/src/app/main.go","line":42,"function":"main.main"}}`+"\n", buf.String())
+}
+
+func TestHandler_stack(t *testing.T) {
+	e := &slog.Entry{
+		Level:   slog.ErrorLevel,
+		Time:    time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC),
+		Message: "boom",
+		Stack: []slog.Caller{
+			{File: "/src/app/main.go", Line: 42, Function: "main.run"},
+			{File: "/src/app/main.go", Line: 10, Function: "main.main"},
+		},
+	}
+
+	var buf bytes.Buffer
+	assert.NoError(t, New(&buf).HandleLog(e))
+	assert.Equal(t, `{"fields":{},"level":"error","time":"2020-01-02T03:04:05Z","msg":"boom","stack":[`+
+		`{"file":"/src/app/main.go","line":42,"function":"main.run"},`+
+		`{"file":"/src/app/main.go","line":10,"function":"main.main"}]}`+"\n", buf.String())
+}
@@ -4,7 +4,10 @@ package logfmt
 import (
 	"io"
 	"os"
+	"strconv"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/go-logfmt/logfmt"
 	"github.com/joshuarubin/slog"
@@ -26,7 +29,8 @@ func New(w io.Writer) *Handler {
 	}
 }
 
-// HandleLog implements slog.Handler.
+// HandleLog implements slog.Handler. Fields are written in the order they
+// were added, so output is the same across runs.
 func (h *Handler) HandleLog(e *slog.Entry) error {
 	h.mu.Lock()
 	defer h.mu.Unlock()
@@ -43,11 +47,57 @@ func (h *Handler) HandleLog(e *slog.Entry) error {
 		return err
 	}
 
-	for k, v := range e.Fields {
-		if err := h.enc.EncodeKeyval(k, v); err != nil {
+	if e.Caller != nil {
+		if err := h.enc.EncodeKeyval("caller", e.Caller.String()); err != nil {
+			return err
+		}
+	}
+
+	for _, f := range e.AllFields() {
+		if err := h.enc.EncodeKeyval(f.Key, value(f)); err != nil {
+			return err
+		}
+	}
+
+	if len(e.Stack) > 0 {
+		if err := h.enc.EncodeKeyval("stack", stack(e.Stack)); err != nil {
 			return err
 		}
 	}
 
 	return h.enc.EndRecord()
 }
+
+// value returns the value of f to encode. Typed fields are formatted here so
+// that the encoder doesn't need to fall back to reflection.
+func value(f slog.Field) interface{} {
+	switch f.Type {
+	case slog.StringType:
+		return f.String
+	case slog.IntType:
+		return strconv.FormatInt(f.Integer, 10)
+	case slog.UintType:
+		return strconv.FormatUint(f.Uint64(), 10)
+	case slog.FloatType:
+		return strconv.FormatFloat(f.Float64(), 'g', -1, 64)
+	case slog.BoolType:
+		return strconv.FormatBool(f.Bool())
+	case slog.DurationType:
+		return f.Duration().String()
+	case slog.TimeType:
+		return f.Time().Format(time.RFC3339Nano)
+	}
+
+	return f.Interface
+}
+
+// stack formats s as a single value of space separated "function@file:line"
+// frames.
+func stack(s slog.Stack) string {
+	frames := make([]string, len(s))
+	for i, c := range s {
+		frames[i] = c.Function + "@" + c.String()
+	}
+
+	return strings.Join(frames, " ")
+}
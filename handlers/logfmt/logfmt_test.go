@@ -0,0 +1,71 @@
+package logfmt_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/joshuarubin/slog"
+	"github.com/joshuarubin/slog/handlers/logfmt"
+	"github.com/joshuarubin/slog/handlers/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_stable(t *testing.T) {
+	m := memory.New()
+
+	l := slog.New()
+	l.RegisterHandler(slog.InfoLevel, m)
+
+	for i := 0; i < 20; i++ {
+		l.With(slog.String("user", "tobi"), slog.Int("id", 1)).
+			WithFields(slog.Fields{
+				"file": "sloth.png",
+				"type": "image/png",
+				"size": 1 << 20,
+				"dir":  "/tmp",
+			}).
+			With(slog.Duration("took", time.Second), slog.Bool("ok", true)).
+			Info("upload")
+	}
+
+	const expect = `time=2020-01-02T03:04:05Z level=info message=upload user=tobi id=1 dir=/tmp file=sloth.png size=1048576 type=image/png took=1s ok=true` + "\n"
+
+	for _, e := range m.Entries {
+		var buf bytes.Buffer
+		e.Time = time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+
+		assert.NoError(t, logfmt.New(&buf).HandleLog(e))
+		assert.Equal(t, expect, buf.String())
+	}
+}
+
+func TestHandler_caller(t *testing.T) {
+	e := &slog.Entry{
+		FieldList: []slog.Field{slog.String("user", "tobi")},
+		Level:     slog.InfoLevel,
+		Time:      time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC),
+		Message:   "upload",
+		Caller:    &slog.Caller{File: "/src/app/main.go", Line: 42, Function: "main.main"},
+	}
+
+	var buf bytes.Buffer
+	assert.NoError(t, logfmt.New(&buf).HandleLog(e))
+	assert.Equal(t, "time=2020-01-02T03:04:05Z level=info message=upload caller=app/main.go:42 user=tobi\n", buf.String())
+}
+
+func TestHandler_stack(t *testing.T) {
+	e := &slog.Entry{
+		Level:   slog.ErrorLevel,
+		Time:    time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC),
+		Message: "boom",
+		Stack: []slog.Caller{
+			{File: "/src/app/main.go", Line: 42, Function: "main.run"},
+			{File: "/src/app/main.go", Line: 10, Function: "main.main"},
+		},
+	}
+
+	var buf bytes.Buffer
+	assert.NoError(t, logfmt.New(&buf).HandleLog(e))
+	assert.Equal(t, `time=2020-01-02T03:04:05Z level=error message=boom stack="main.run@app/main.go:42 main.main@app/main.go:10"`+"\n", buf.String())
+}
@@ -0,0 +1,368 @@
+// Package redact implements a handler that removes sensitive data from the
+// fields of entries before passing them to another handler.
+//
+// Fields are matched by key, at any depth of nested maps and structs, and
+// either have their value replaced or are dropped. String values, including the
+// message and the text of errors, are also scrubbed of any substrings matching
+// value patterns such as CreditCard or BearerToken. Structs with unexported
+// fields, whose values can't be read, are scrubbed as formatted with %+v.
+//
+// The entry passed to the wrapped handler is a copy, so other handlers
+// registered with the same Logger receive the original.
+package redact
+
+import (
+	"fmt"
+	"path"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/joshuarubin/slog"
+)
+
+// DefaultReplacement is used when Config.Replacement is not set.
+const DefaultReplacement = "[REDACTED]"
+
+// maxDepth limits recursion into nested values, which also protects against
+// cycles.
+const maxDepth = 16
+
+// Common value patterns.
+var (
+	CreditCard  = regexp.MustCompile(`\b(?:\d[ -]?){12,18}\d\b`)
+	BearerToken = regexp.MustCompile(`(?i)\bbearer\s+[a-z0-9._~+/=-]+`)
+	Email       = regexp.MustCompile(`(?i)\b[a-z0-9._%+-]+@[a-z0-9.-]+\.[a-z]{2,}\b`)
+)
+
+// A Matcher reports whether a field key matches.
+type Matcher func(key string) bool
+
+// Key returns a Matcher for any of the keys, compared case insensitively.
+func Key(keys ...string) Matcher {
+	return func(key string) bool {
+		for _, k := range keys {
+			if strings.EqualFold(k, key) {
+				return true
+			}
+		}
+
+		return false
+	}
+}
+
+// Glob returns a Matcher for keys matching the path.Match pattern, compared
+// case insensitively. It panics if the pattern is malformed.
+func Glob(pattern string) Matcher {
+	pattern = strings.ToLower(pattern)
+
+	if _, err := path.Match(pattern, ""); err != nil {
+		panic(fmt.Sprintf("redact: invalid glob %q: %s", pattern, err))
+	}
+
+	return func(key string) bool {
+		ok, _ := path.Match(pattern, strings.ToLower(key))
+		return ok
+	}
+}
+
+// Regexp returns a Matcher for keys matching the regular expression. It panics
+// if the expression can not be compiled.
+func Regexp(expr string) Matcher {
+	return regexp.MustCompile(expr).MatchString
+}
+
+// Config for a Handler.
+type Config struct {
+	// Redact has the values of matching fields replaced.
+	Redact []Matcher
+
+	// Drop has matching fields removed.
+	Drop []Matcher
+
+	// Values are patterns that are replaced within string values.
+	Values []*regexp.Regexp
+
+	// Replacement for redacted values. Defaults to DefaultReplacement.
+	Replacement string
+}
+
+// Handler implementation.
+type Handler struct {
+	handler slog.Handler
+	config  Config
+}
+
+// assert interface compliance.
+var (
+	_ slog.Flusher = (*Handler)(nil)
+	_ slog.Closer  = (*Handler)(nil)
+)
+
+// New handler that passes redacted copies of entries to h.
+func New(h slog.Handler, c Config) *Handler {
+	if c.Replacement == "" {
+		c.Replacement = DefaultReplacement
+	}
+
+	return &Handler{
+		handler: h,
+		config:  c,
+	}
+}
+
+// HandleLog implements slog.Handler.
+func (h *Handler) HandleLog(e *slog.Entry) error {
+	fields := e.AllFields()
+	list := make([]slog.Field, 0, len(fields))
+
+	for _, f := range fields {
+		if f, keep := h.typedField(f); keep {
+			list = append(list, f)
+		}
+	}
+
+	c := e.WithFieldList(list)
+	c.Message = h.scrub(e.Message)
+
+	return h.handler.HandleLog(c)
+}
+
+// Flush implements slog.Flusher.
+func (h *Handler) Flush() error {
+	return slog.FlushHandler(h.handler)
+}
+
+// Close implements slog.Closer.
+func (h *Handler) Close() error {
+	return slog.CloseHandler(h.handler)
+}
+
+func match(matchers []Matcher, key string) bool {
+	for _, m := range matchers {
+		if m(key) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// field returns the value to use for the field, whether it should be kept
+// rather than dropped, and whether it changed.
+func (h *Handler) field(key string, value interface{}, depth int) (v interface{}, keep, changed bool) {
+	if match(h.config.Drop, key) {
+		return nil, false, true
+	}
+
+	if match(h.config.Redact, key) {
+		return h.config.Replacement, true, true
+	}
+
+	v, changed = h.value(value, depth+1)
+	return v, true, changed
+}
+
+// typedField returns the field to use in place of f, and whether it should be
+// kept rather than dropped. Numeric, bool, duration and time fields are only
+// matched by key.
+func (h *Handler) typedField(f slog.Field) (slog.Field, bool) {
+	switch f.Type {
+	case slog.AnyType, slog.ErrorType:
+		v, keep, changed := h.field(f.Key, f.Interface, 0)
+		if keep && changed {
+			return slog.Any(f.Key, v), true
+		}
+
+		return f, keep
+	}
+
+	if match(h.config.Drop, f.Key) {
+		return f, false
+	}
+
+	if match(h.config.Redact, f.Key) {
+		return slog.String(f.Key, h.config.Replacement), true
+	}
+
+	if f.Type == slog.StringType {
+		if s := h.scrub(f.String); s != f.String {
+			return slog.String(f.Key, s), true
+		}
+	}
+
+	return f, true
+}
+
+func (h *Handler) scrub(s string) string {
+	for _, re := range h.config.Values {
+		s = re.ReplaceAllLiteralString(s, h.config.Replacement)
+	}
+
+	return s
+}
+
+// value returns the redacted value, and whether it differs from value. Values
+// that don't need to change are returned as is, others are returned as a
+// string, map[string]interface{} or []interface{}.
+func (h *Handler) value(value interface{}, depth int) (interface{}, bool) {
+	if value == nil || depth > maxDepth {
+		return value, false
+	}
+
+	switch v := value.(type) {
+	case string:
+		s := h.scrub(v)
+		return s, s != v
+	case error:
+		return h.stringer(value, v.Error())
+	}
+
+	rv := reflect.ValueOf(value)
+	for rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return value, false
+		}
+
+		rv = rv.Elem()
+	}
+
+	switch rv.Kind() {
+	case reflect.Map:
+		if rv.Type().Key().Kind() == reflect.String {
+			return h.mapValue(value, rv, depth)
+		}
+	case reflect.Struct:
+		if ret, changed := h.structValue(value, rv, depth); changed {
+			return ret, true
+		}
+	case reflect.Slice, reflect.Array:
+		return h.sliceValue(value, rv, depth)
+	}
+
+	if s, ok := value.(fmt.Stringer); ok {
+		return h.stringer(value, s.String())
+	}
+
+	return value, false
+}
+
+// stringer returns the scrubbed string if scrubbing changed it.
+func (h *Handler) stringer(value interface{}, s string) (interface{}, bool) {
+	if r := h.scrub(s); r != s {
+		return r, true
+	}
+
+	return value, false
+}
+
+func (h *Handler) mapValue(value interface{}, rv reflect.Value, depth int) (interface{}, bool) {
+	ret := make(map[string]interface{}, rv.Len())
+	changed := false
+
+	iter := rv.MapRange()
+	for iter.Next() {
+		k := iter.Key().String()
+
+		v, keep, c := h.field(k, iter.Value().Interface(), depth)
+		changed = changed || c
+
+		if keep {
+			ret[k] = v
+		}
+	}
+
+	if !changed {
+		return value, false
+	}
+
+	return ret, true
+}
+
+// structValue returns the struct as a map if any of its fields change.
+// Otherwise, if it has unexported fields, which can't be read but would still
+// be printed by handlers that format it with fmt, it is formatted with %+v and
+// the string is used if scrubbing changes it.
+func (h *Handler) structValue(value interface{}, rv reflect.Value, depth int) (interface{}, bool) {
+	ret := map[string]interface{}{}
+	changed, unexported := false, false
+	t := rv.Type()
+
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if sf.PkgPath != "" {
+			// unexported values are only matched by name. If the struct
+			// changes, those that don't match are left out, as with
+			// encoding/json.
+			v, keep, c := h.field(sf.Name, nil, depth)
+			if !c {
+				unexported = true
+				continue
+			}
+
+			changed = true
+
+			if keep {
+				ret[sf.Name] = v
+			}
+
+			continue
+		}
+
+		name := sf.Name
+		if tag := sf.Tag.Get("json"); tag != "" {
+			if tag == "-" {
+				continue
+			}
+
+			if n := strings.Split(tag, ",")[0]; n != "" {
+				name = n
+			}
+		}
+
+		v, keep, c := h.field(name, rv.Field(i).Interface(), depth)
+		changed = changed || c
+
+		if keep {
+			ret[name] = v
+		}
+	}
+
+	if changed {
+		return ret, true
+	}
+
+	if unexported && len(h.config.Values) > 0 {
+		return h.stringer(value, fmt.Sprintf("%+v", value))
+	}
+
+	return value, false
+}
+
+func (h *Handler) sliceValue(value interface{}, rv reflect.Value, depth int) (interface{}, bool) {
+	if rv.Kind() == reflect.Slice && rv.Type().Elem().Kind() == reflect.Uint8 {
+		return value, false // []byte
+	}
+
+	ret := make([]interface{}, rv.Len())
+	changed := false
+
+	for i := range ret {
+		v, c := h.value(rv.Index(i).Interface(), depth+1)
+		changed = changed || c
+		ret[i] = v
+	}
+
+	if !changed {
+		return value, false
+	}
+
+	return ret, true
+}
+
+// Middleware returns slog.Middleware that wraps handlers with New.
+func Middleware(c Config) slog.Middleware {
+	return func(h slog.Handler) slog.Handler {
+		return New(h, c)
+	}
+}
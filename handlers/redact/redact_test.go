@@ -0,0 +1,146 @@
+package redact_test
+
+import (
+	"bytes"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/joshuarubin/slog"
+	"github.com/joshuarubin/slog/handlers/memory"
+	"github.com/joshuarubin/slog/handlers/redact"
+	"github.com/joshuarubin/slog/handlers/text"
+	"github.com/stretchr/testify/assert"
+)
+
+type credentials struct {
+	User     string `json:"user"`
+	Password string `json:"password"`
+	Ignored  string `json:"-"`
+	internal string
+}
+
+type creds struct {
+	User     string
+	password string
+	token    string
+}
+
+type payment struct {
+	Name string
+	card string
+}
+
+type request struct {
+	Path  string
+	Creds *credentials
+}
+
+func TestHandler(t *testing.T) {
+	m, raw := memory.New(), memory.New()
+
+	h := redact.New(m, redact.Config{
+		Redact: []redact.Matcher{
+			redact.Key("password"),
+			redact.Glob("*_token"),
+			redact.Regexp(`^secret\d+$`),
+		},
+		Drop:   []redact.Matcher{redact.Key("ssn")},
+		Values: []*regexp.Regexp{redact.CreditCard, redact.BearerToken, redact.Email},
+	})
+
+	l := slog.New()
+	l.RegisterHandler(slog.InfoLevel, h)
+	l.RegisterHandler(slog.InfoLevel, raw)
+
+	now := time.Now()
+	fields := slog.Fields{
+		"Password":     "hunter2",
+		"access_token": "abc",
+		"secret1":      "xyz",
+		"ssn":          "078-05-1120",
+		"card":         "card 4111 1111 1111 1111 expired",
+		"auth":         errors.New("invalid header: Bearer abc.def"),
+		"user":         "tobi",
+		"time":         now,
+		"nested": map[string]interface{}{
+			"email":    "tobi@example.com",
+			"password": "hunter2",
+			"ok":       1,
+		},
+		"request": &request{
+			Path:  "/login",
+			Creds: &credentials{User: "tobi", Password: "hunter2", Ignored: "x", internal: "y"},
+		},
+		"plain": &request{Path: "/"},
+		"list":  []string{"ok", "bearer abc"},
+	}
+
+	l.WithFields(fields).Info("sent to tobi@example.com")
+
+	e := m.Entries[0]
+	assert.Equal(t, "sent to [REDACTED]", e.Message)
+	assert.Equal(t, slog.Fields{
+		"Password":     "[REDACTED]",
+		"access_token": "[REDACTED]",
+		"secret1":      "[REDACTED]",
+		"card":         "card [REDACTED] expired",
+		"auth":         "invalid header: [REDACTED]",
+		"user":         "tobi",
+		"time":         now,
+		"nested": map[string]interface{}{
+			"email":    "[REDACTED]",
+			"password": "[REDACTED]",
+			"ok":       1,
+		},
+		"request": map[string]interface{}{
+			"Path": "/login",
+			"Creds": map[string]interface{}{
+				"user":     "tobi",
+				"password": "[REDACTED]",
+			},
+		},
+		"plain": fields["plain"],
+		"list":  []interface{}{"ok", "[REDACTED]"},
+	}, e.Fields)
+
+	assert.Equal(t, "sent to tobi@example.com", raw.Entries[0].Message)
+	assert.Equal(t, fields, raw.Entries[0].Fields)
+}
+
+func TestHandler_unexported(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := slog.New()
+	l.RegisterHandler(slog.InfoLevel, redact.New(text.New(&buf), redact.Config{
+		Redact: []redact.Matcher{redact.Key("password")},
+		Drop:   []redact.Matcher{redact.Key("token")},
+	}))
+
+	l.WithField("c", &creds{User: "tobi", password: "hunter2", token: "abc"}).Info("login")
+
+	assert.Contains(t, buf.String(), "c=map[User:tobi password:[REDACTED]]")
+	assert.NotContains(t, buf.String(), "hunter2")
+	assert.NotContains(t, buf.String(), "abc")
+}
+
+func TestHandler_unexportedValues(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := slog.New()
+	l.RegisterHandler(slog.InfoLevel, redact.New(text.New(&buf), redact.Config{
+		Values: []*regexp.Regexp{redact.CreditCard},
+	}))
+
+	p := payment{Name: "tobi", card: "4111 1111 1111 1111"}
+	l.WithField("p", p).Info("value")
+	l.WithField("p", &p).Info("pointer")
+	l.WithField("p", payment{Name: "tobi"}).Info("clean")
+
+	out := buf.String()
+	assert.NotContains(t, out, "4111")
+	assert.Contains(t, out, `p="{Name:tobi card:[REDACTED]}"`)
+	assert.Contains(t, out, `p="&{Name:tobi card:[REDACTED]}"`)
+	assert.Contains(t, out, "p={tobi }")
+}
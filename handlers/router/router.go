@@ -0,0 +1,174 @@
+// Package router implements a handler that dispatches entries to the handlers
+// of the branches that select them, by level range and by predicates on their
+// fields or message.
+//
+// Unlike the maximum level of a handler registered with a slog.Logger, a
+// branch can also have a minimum level, or pass only a single level. Entries
+// that no branch selects are passed to the Default handler, if any.
+package router
+
+import (
+	"reflect"
+	"regexp"
+
+	"github.com/joshuarubin/slog"
+)
+
+// Branch selects the entries passed to a handler. Levels are compared by
+// verbosity, so custom levels registered with slog.RegisterLevel fall between
+// the built in ones.
+type Branch struct {
+	Handler slog.Handler
+
+	// Min is the least verbose level passed to Handler. For example a Min of
+	// slog.InfoLevel excludes warnings and errors. If it is nil there is no
+	// lower bound.
+	Min slog.Leveler
+
+	// Max is the most verbose level passed to Handler, the same as the level a
+	// handler is registered with. If it is nil there is no upper bound.
+	Max slog.Leveler
+
+	// Only, if set, is the only level passed to Handler. It takes precedence
+	// over Min and Max.
+	Only slog.Leveler
+
+	// Filter, if set, must return true for an entry to be passed to Handler.
+	Filter func(*slog.Entry) bool
+}
+
+// Match reports whether the branch selects e.
+func (b Branch) Match(e *slog.Entry) bool {
+	v := e.Level.Verbosity()
+
+	if b.Only != nil {
+		if v != b.Only.Level().Verbosity() {
+			return false
+		}
+	} else {
+		if b.Min != nil && v < b.Min.Level().Verbosity() {
+			return false
+		}
+
+		if b.Max != nil && v > b.Max.Level().Verbosity() {
+			return false
+		}
+	}
+
+	return b.Filter == nil || b.Filter(e)
+}
+
+// Config for a Handler.
+type Config struct {
+	// Branches are checked in order.
+	Branches []Branch
+
+	// Default, if set, is passed entries that no branch selects.
+	Default slog.Handler
+
+	// First passes each entry only to the first branch that selects it, rather
+	// than to every one.
+	First bool
+}
+
+// Handler implementation.
+type Handler struct {
+	config Config
+}
+
+// assert interface compliance.
+var (
+	_ slog.Flusher = (*Handler)(nil)
+	_ slog.Closer  = (*Handler)(nil)
+)
+
+// New handler.
+func New(c Config) *Handler {
+	return &Handler{
+		config: c,
+	}
+}
+
+// HandleLog implements slog.Handler. Every selected handler is passed the
+// entry even if some fail, and the first error is returned.
+func (h *Handler) HandleLog(e *slog.Entry) error {
+	var (
+		err     error
+		matched bool
+	)
+
+	for _, b := range h.config.Branches {
+		if !b.Match(e) {
+			continue
+		}
+
+		matched = true
+
+		if herr := b.Handler.HandleLog(e); herr != nil && err == nil {
+			err = herr
+		}
+
+		if h.config.First {
+			break
+		}
+	}
+
+	if !matched && h.config.Default != nil {
+		return h.config.Default.HandleLog(e)
+	}
+
+	return err
+}
+
+// Flush implements slog.Flusher, flushing every handler that is a
+// slog.Flusher.
+func (h *Handler) Flush() error {
+	return slog.EachHandler(h.handlers(), slog.FlushHandler)
+}
+
+// Close implements slog.Closer, closing every handler that is a slog.Closer,
+// and flushing those that are only slog.Flushers.
+func (h *Handler) Close() error {
+	return slog.EachHandler(h.handlers(), slog.CloseHandler)
+}
+
+// handlers returns the handler of every branch and the default handler.
+func (h *Handler) handlers() []slog.Handler {
+	ret := make([]slog.Handler, 0, len(h.config.Branches)+1)
+	for _, b := range h.config.Branches {
+		ret = append(ret, b.Handler)
+	}
+
+	if h.config.Default != nil {
+		ret = append(ret, h.config.Default)
+	}
+
+	return ret
+}
+
+// HasField returns a Filter that selects entries with a field named key.
+func HasField(key string) func(*slog.Entry) bool {
+	return func(e *slog.Entry) bool {
+		_, ok := e.Value(key)
+		return ok
+	}
+}
+
+// FieldEquals returns a Filter that selects entries with a field named key
+// whose value is equal to value, as compared by reflect.DeepEqual. The values
+// of typed fields are those returned by slog.Field.Value, such as int64 for
+// slog.Int.
+func FieldEquals(key string, value interface{}) func(*slog.Entry) bool {
+	return func(e *slog.Entry) bool {
+		v, ok := e.Value(key)
+		return ok && reflect.DeepEqual(v, value)
+	}
+}
+
+// MessageMatches returns a Filter that selects entries whose message matches
+// re.
+func MessageMatches(re *regexp.Regexp) func(*slog.Entry) bool {
+	return func(e *slog.Entry) bool {
+		return re.MatchString(e.Message)
+	}
+}
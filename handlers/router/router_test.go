@@ -0,0 +1,139 @@
+package router_test
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/joshuarubin/slog"
+	"github.com/joshuarubin/slog/handlers/memory"
+	"github.com/joshuarubin/slog/handlers/router"
+	"github.com/stretchr/testify/assert"
+)
+
+func messages(m *memory.Handler) []string {
+	ret := make([]string, len(m.Entries))
+	for i, e := range m.Entries {
+		ret[i] = e.Message
+	}
+
+	return ret
+}
+
+func TestHandler(t *testing.T) {
+	errs, audit, warn, rest := memory.New(), memory.New(), memory.New(), memory.New()
+
+	h := router.New(router.Config{
+		Branches: []router.Branch{
+			{Handler: errs, Max: slog.ErrorLevel},
+			{Handler: audit, Filter: router.FieldEquals("audit", true)},
+			{Handler: warn, Only: slog.WarnLevel},
+		},
+		Default: rest,
+	})
+
+	l := slog.New()
+	l.RegisterHandler(slog.DebugLevel, h)
+
+	l.Debug("debug")
+	l.Info("info")
+	l.Warn("warn")
+	l.Error("error")
+	l.WithField("audit", true).Info("login")
+	l.WithField("audit", true).Error("denied")
+
+	assert.Equal(t, []string{"error", "denied"}, messages(errs))
+	assert.Equal(t, []string{"login", "denied"}, messages(audit))
+	assert.Equal(t, []string{"warn"}, messages(warn))
+	assert.Equal(t, []string{"debug", "info"}, messages(rest))
+}
+
+func TestHandler_range(t *testing.T) {
+	m := memory.New()
+	maxLevel := slog.NewLevelVar(slog.InfoLevel)
+
+	h := router.New(router.Config{
+		Branches: []router.Branch{{
+			Handler: m,
+			Min:     slog.WarnLevel,
+			Max:     maxLevel,
+			Filter:  router.MessageMatches(regexp.MustCompile(`^upload`)),
+		}},
+	})
+
+	l := slog.New()
+	l.RegisterHandler(slog.DebugLevel, h)
+
+	l.Error("upload failed")
+	l.Warn("upload slow")
+	l.Info("upload complete")
+	l.Info("login")
+	l.Debug("upload progress")
+
+	maxLevel.SetLevel(slog.DebugLevel)
+	l.Debug("upload done")
+
+	assert.Equal(t, []string{"upload slow", "upload complete", "upload done"}, messages(m))
+}
+
+func TestHandler_First(t *testing.T) {
+	a, b := memory.New(), memory.New()
+
+	h := router.New(router.Config{
+		Branches: []router.Branch{
+			{Handler: a, Filter: router.HasField("user")},
+			{Handler: b},
+		},
+		First: true,
+	})
+
+	l := slog.New()
+	l.RegisterHandler(slog.InfoLevel, h)
+
+	l.With(slog.String("user", "tobi")).Info("login")
+	l.Info("tick")
+
+	assert.Equal(t, []string{"login"}, messages(a))
+	assert.Equal(t, []string{"tick"}, messages(b))
+}
+
+func TestHandler_errors(t *testing.T) {
+	errBoom := errors.New("boom")
+	m := memory.New()
+
+	h := router.New(router.Config{
+		Branches: []router.Branch{
+			{Handler: slog.HandlerFunc(func(*slog.Entry) error { return errBoom })},
+			{Handler: m},
+		},
+	})
+
+	assert.Equal(t, errBoom, h.HandleLog(&slog.Entry{Message: "boom"}))
+	assert.Equal(t, 1, len(m.Entries))
+}
+
+type closer struct {
+	*memory.Handler
+	closed int
+}
+
+func (c *closer) Close() error {
+	c.closed++
+	return nil
+}
+
+func TestHandler_Close(t *testing.T) {
+	a, d := &closer{Handler: memory.New()}, &closer{Handler: memory.New()}
+
+	h := router.New(router.Config{
+		Branches: []router.Branch{
+			{Handler: a, Max: slog.ErrorLevel},
+			{Handler: a, Only: slog.DebugLevel},
+		},
+		Default: d,
+	})
+
+	assert.NoError(t, h.Close())
+	assert.Equal(t, 1, a.closed)
+	assert.Equal(t, 1, d.closed)
+}
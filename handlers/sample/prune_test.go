@@ -0,0 +1,44 @@
+package sample
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/joshuarubin/slog"
+	"github.com/joshuarubin/slog/handlers/discard"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_prune(t *testing.T) {
+	now := time.Now()
+
+	h := New(discard.New(), Config{
+		Fields: []string{"id"},
+		Now:    func() time.Time { return now },
+	})
+
+	l := slog.New()
+	l.RegisterHandler(slog.InfoLevel, h)
+
+	log := func(from, to int) {
+		for i := from; i < to; i++ {
+			l.WithField("id", i).Info("hit")
+			l.WithField("id", i).Info("hit") // dropped
+		}
+	}
+
+	// no group has expired, so all of them are removed
+	log(0, maxGroups+1)
+	assert.Equal(t, 1, len(h.groups))
+
+	// expired groups are removed even with dropped entries to report, the
+	// group for 1 has started a new interval and is kept
+	log(1, maxGroups)
+	now = now.Add(DefaultInterval)
+	log(1, 2)
+	log(maxGroups+1, maxGroups+2)
+	assert.Equal(t, 2, len(h.groups))
+	assert.Contains(t, h.groups, "info\x00hit\x001")
+	assert.Contains(t, h.groups, "info\x00hit\x00"+strconv.Itoa(maxGroups+1))
+}
@@ -0,0 +1,192 @@
+// Package sample implements a handler that limits how often similar entries
+// are passed to another handler.
+//
+// Entries are grouped by level, message and, optionally, the values of some of
+// their fields. In every interval the first Config.First entries of a group are
+// passed on, and after that every Config.Thereafter-th. The number of entries
+// that were dropped is added to the next entry of the group that is passed on
+// as the "dropped" field.
+package sample
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/joshuarubin/slog"
+)
+
+// DefaultInterval is used when Config.Interval is not set.
+const DefaultInterval = time.Second
+
+// DroppedKey is the field that holds the number of entries that were dropped.
+const DroppedKey = "dropped"
+
+// maxGroups is the number of groups that are kept before those whose interval
+// has ended are removed.
+const maxGroups = 4096
+
+// Config for a Handler.
+type Config struct {
+	// First is the number of entries per group passed on in each interval.
+	First int
+
+	// Thereafter is the rate at which entries are passed on once First have
+	// been. If it is zero, all of them are dropped.
+	Thereafter int
+
+	// Interval after which the counts are reset. Defaults to
+	// DefaultInterval.
+	Interval time.Duration
+
+	// Fields are the keys of fields whose values, along with the level and
+	// message, determine the group of an entry.
+	Fields []string
+
+	// Now returns the current time. Defaults to time.Now.
+	Now func() time.Time
+}
+
+type group struct {
+	start   time.Time
+	count   int
+	dropped int
+}
+
+// Handler implementation.
+type Handler struct {
+	handler slog.Handler
+	config  Config
+
+	mu     sync.Mutex
+	groups map[string]*group
+}
+
+// assert interface compliance.
+var (
+	_ slog.Flusher = (*Handler)(nil)
+	_ slog.Closer  = (*Handler)(nil)
+)
+
+// New handler that passes a sample of entries to h.
+func New(h slog.Handler, c Config) *Handler {
+	if c.Interval <= 0 {
+		c.Interval = DefaultInterval
+	}
+
+	if c.Now == nil {
+		c.Now = time.Now
+	}
+
+	return &Handler{
+		handler: h,
+		config:  c,
+		groups:  map[string]*group{},
+	}
+}
+
+// Middleware returns slog.Middleware that wraps handlers with New.
+func Middleware(c Config) slog.Middleware {
+	return func(h slog.Handler) slog.Handler {
+		return New(h, c)
+	}
+}
+
+func (h *Handler) key(e *slog.Entry) string {
+	var b strings.Builder
+
+	b.WriteString(e.Level.String())
+	b.WriteByte(0)
+	b.WriteString(e.Message)
+
+	for _, k := range h.config.Fields {
+		b.WriteByte(0)
+		v, _ := e.Value(k)
+		fmt.Fprint(&b, v)
+	}
+
+	return b.String()
+}
+
+// sample reports whether e should be passed on, and how many entries of its
+// group have been dropped since the last one that was.
+func (h *Handler) sample(e *slog.Entry) (bool, int) {
+	now := h.config.Now()
+	key := h.key(e)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	g, ok := h.groups[key]
+	if !ok {
+		if len(h.groups) >= maxGroups {
+			h.prune(now)
+		}
+
+		g = &group{start: now}
+		h.groups[key] = g
+	}
+
+	if now.Sub(g.start) >= h.config.Interval {
+		g.start = now
+		g.count = 0
+	}
+
+	g.count++
+
+	n := g.count - h.config.First
+	if n > 0 && (h.config.Thereafter <= 0 || n%h.config.Thereafter != 0) {
+		g.dropped++
+		return false, 0
+	}
+
+	dropped := g.dropped
+	g.dropped = 0
+
+	return true, dropped
+}
+
+// prune removes groups whose interval has ended, along with any count of
+// dropped entries they have yet to report. If none have ended, all of them are
+// removed, so that the number of groups stays bounded however many distinct
+// field values are logged. It must be called with h.mu held.
+func (h *Handler) prune(now time.Time) {
+	for key, g := range h.groups {
+		if now.Sub(g.start) >= h.config.Interval {
+			delete(h.groups, key)
+		}
+	}
+
+	if len(h.groups) >= maxGroups {
+		h.groups = map[string]*group{}
+	}
+}
+
+// HandleLog implements slog.Handler.
+func (h *Handler) HandleLog(e *slog.Entry) error {
+	ok, dropped := h.sample(e)
+	if !ok {
+		return nil
+	}
+
+	if _, ok := e.Value(DroppedKey); dropped > 0 && !ok {
+		fields := e.AllFields()
+		list := make([]slog.Field, len(fields), len(fields)+1)
+		copy(list, fields)
+
+		e = e.WithFieldList(append(list, slog.Any(DroppedKey, dropped)))
+	}
+
+	return h.handler.HandleLog(e)
+}
+
+// Flush implements slog.Flusher.
+func (h *Handler) Flush() error {
+	return slog.FlushHandler(h.handler)
+}
+
+// Close implements slog.Closer.
+func (h *Handler) Close() error {
+	return slog.CloseHandler(h.handler)
+}
@@ -0,0 +1,82 @@
+package sample_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/joshuarubin/slog"
+	"github.com/joshuarubin/slog/handlers/memory"
+	"github.com/joshuarubin/slog/handlers/sample"
+	"github.com/stretchr/testify/assert"
+)
+
+type clock struct {
+	now time.Time
+}
+
+func (c *clock) Now() time.Time {
+	return c.now
+}
+
+func dropped(h *memory.Handler) []interface{} {
+	var ret []interface{}
+	for _, e := range h.Entries {
+		ret = append(ret, e.Fields[sample.DroppedKey])
+	}
+	return ret
+}
+
+func TestHandler(t *testing.T) {
+	c := &clock{now: time.Date(2019, 2, 3, 4, 5, 6, 0, time.UTC)}
+	m := memory.New()
+
+	l := slog.New()
+	l.RegisterHandler(slog.InfoLevel, sample.New(m, sample.Config{
+		First:      2,
+		Thereafter: 3,
+		Interval:   time.Minute,
+		Now:        c.Now,
+	}))
+
+	for i := 0; i < 10; i++ {
+		l.Error("boom")
+	}
+
+	// 1, 2, 5 and 8 are logged
+	assert.Equal(t, []interface{}{nil, nil, 2, 2}, dropped(m))
+
+	l.Warn("boom")
+	l.Error("other")
+	assert.Equal(t, 6, len(m.Entries))
+
+	c.now = c.now.Add(time.Minute)
+	l.Error("boom")
+
+	// a new interval has started, the dropped 9 and 10 are reported
+	assert.Equal(t, 7, len(m.Entries))
+	assert.Equal(t, 2, m.Entries[6].Fields[sample.DroppedKey])
+}
+
+func TestHandler_Fields(t *testing.T) {
+	c := &clock{now: time.Now()}
+	m := memory.New()
+
+	l := slog.New()
+	l.RegisterHandler(slog.InfoLevel, sample.Middleware(sample.Config{
+		First:  1,
+		Fields: []string{"user"},
+		Now:    c.Now,
+	})(m))
+
+	for i := 0; i < 3; i++ {
+		l.WithField("user", "tobi").WithField("i", i).Error("boom")
+		l.WithField("user", "loki").WithField("i", i).Error("boom")
+	}
+
+	assert.Equal(t, 2, len(m.Entries))
+
+	c.now = c.now.Add(sample.DefaultInterval)
+	l.WithField("user", "tobi").Error("boom")
+
+	assert.Equal(t, []interface{}{nil, nil, 2}, dropped(m))
+}
@@ -10,6 +10,7 @@ import (
 	"os"
 	"runtime"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -49,6 +50,37 @@ var Colors = [...]int{
 	slog.PanicLevel: red,
 }
 
+// custom colors set with SetColor.
+var (
+	colorMu sync.RWMutex
+	colors  = map[slog.Level]int{}
+)
+
+// SetColor sets the color of level, overriding Colors. It is for custom levels
+// registered with slog.RegisterLevel, which are otherwise gray, and is safe to
+// call while logging.
+func SetColor(level slog.Level, color int) {
+	colorMu.Lock()
+	colors[level] = color
+	colorMu.Unlock()
+}
+
+// levelColor returns the color of level.
+func levelColor(level slog.Level) int {
+	colorMu.RLock()
+	color, ok := colors[level]
+	colorMu.RUnlock()
+
+	switch {
+	case ok:
+		return color
+	case int(level) < len(Colors):
+		return Colors[level]
+	}
+
+	return gray
+}
+
 // field used for sorting.
 type field struct {
 	Name  string
@@ -89,14 +121,18 @@ const DefaultTimestampFormat = time.RFC3339
 func (h *Handler) HandleLog(e *slog.Entry) error {
 	var fields []field
 
-	for k, v := range e.Fields {
-		fields = append(fields, field{k, v})
+	for _, f := range e.AllFields() {
+		fields = append(fields, field{f.Key, value(f)})
 	}
 
 	if !h.DisableSorting {
 		sort.Sort(byName(fields))
 	}
 
+	if e.Caller != nil {
+		fields = append(fields, field{"caller", e.Caller.String()})
+	}
+
 	isColorTerminal := isTerminal && (runtime.GOOS != "windows")
 	isColored := (h.ForceColors || isColorTerminal) && !h.DisableColors
 
@@ -127,11 +163,16 @@ func (h *Handler) HandleLog(e *slog.Entry) error {
 	}
 
 	fmt.Fprintln(h.Writer)
+
+	for _, c := range e.Stack {
+		fmt.Fprintf(h.Writer, "\t%s\n\t\t%s:%d\n", c.Function, c.File, c.Line)
+	}
+
 	return nil
 }
 
 func (h *Handler) printColored(e *slog.Entry, fields []field, timestampFormat string) {
-	color := Colors[e.Level]
+	color := levelColor(e.Level)
 
 	if h.DisableTimestamp {
 		fmt.Fprintf(h.Writer, "\033[%dm%5s\033[0m %-25s", color, strings.ToUpper(e.Level.String()), e.Message)
@@ -143,10 +184,41 @@ func (h *Handler) printColored(e *slog.Entry, fields []field, timestampFormat st
 	}
 
 	for _, f := range fields {
+		if v, ok := f.Value.(raw); ok {
+			fmt.Fprintf(h.Writer, " \033[%dm%s\033[0m=%s", color, f.Name, string(v))
+			continue
+		}
+
 		fmt.Fprintf(h.Writer, " \033[%dm%s\033[0m=%+v", color, f.Name, f.Value)
 	}
 }
 
+// raw is a formatted value that is printed without quoting.
+type raw string
+
+// value returns the value of f to print. Typed fields are formatted here so
+// that printing them doesn't need reflection.
+func value(f slog.Field) interface{} {
+	switch f.Type {
+	case slog.StringType:
+		return f.String
+	case slog.IntType:
+		return raw(strconv.FormatInt(f.Integer, 10))
+	case slog.UintType:
+		return raw(strconv.FormatUint(f.Uint64(), 10))
+	case slog.FloatType:
+		return raw(strconv.FormatFloat(f.Float64(), 'g', -1, 64))
+	case slog.BoolType:
+		return raw(strconv.FormatBool(f.Bool()))
+	case slog.DurationType:
+		return raw(f.Duration().String())
+	case slog.TimeType:
+		return raw(f.Time().String())
+	}
+
+	return f.Interface
+}
+
 func (h *Handler) appendKeyValue(key string, value interface{}) {
 	fmt.Fprintf(h.Writer, "%s=", key)
 
@@ -157,6 +229,8 @@ func (h *Handler) appendKeyValue(key string, value interface{}) {
 		} else {
 			fmt.Fprintf(h.Writer, "%q", value)
 		}
+	case raw:
+		io.WriteString(h.Writer, string(value))
 	case error:
 		errmsg := value.Error()
 		if !needsQuoting(errmsg) {
@@ -0,0 +1,72 @@
+package text_test
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/joshuarubin/slog"
+	"github.com/joshuarubin/slog/handlers/text"
+	"github.com/stretchr/testify/assert"
+)
+
+func plain(buf *bytes.Buffer) *text.Handler {
+	h := text.New(buf)
+	h.DisableColors = true
+	h.DisableTimestamp = true
+	return h
+}
+
+func TestHandler_caller(t *testing.T) {
+	e := &slog.Entry{
+		FieldList: []slog.Field{slog.String("user", "tobi")},
+		Level:     slog.InfoLevel,
+		Message:   "upload",
+		Caller:    &slog.Caller{File: "/src/app/main.go", Line: 42, Function: "main.main"},
+	}
+
+	var buf bytes.Buffer
+	assert.NoError(t, plain(&buf).HandleLog(e))
+	assert.Equal(t, "level=INFO msg=upload user=tobi caller=\"app/main.go:42\" \n", buf.String())
+}
+
+func TestHandler_stack(t *testing.T) {
+	e := &slog.Entry{
+		Level:   slog.ErrorLevel,
+		Message: "boom",
+		Stack: []slog.Caller{
+			{File: "/src/app/main.go", Line: 42, Function: "main.run"},
+			{File: "/src/app/main.go", Line: 10, Function: "main.main"},
+		},
+	}
+
+	var buf bytes.Buffer
+	assert.NoError(t, plain(&buf).HandleLog(e))
+	assert.Equal(t, "level=ERROR msg=boom \n"+
+		"\tmain.run\n\t\t/src/app/main.go:42\n"+
+		"\tmain.main\n\t\t/src/app/main.go:10\n", buf.String())
+}
+
+// notice is a custom level. Levels can't be unregistered, so it is registered
+// once, by TestMain.
+var notice slog.Level
+
+func TestMain(m *testing.M) {
+	notice = slog.MustRegisterLevel("text-notice", 35)
+	os.Exit(m.Run())
+}
+
+func TestSetColor(t *testing.T) {
+	text.SetColor(notice, 36)
+
+	var buf bytes.Buffer
+	h := text.New(&buf)
+	h.ForceColors = true
+	h.DisableTimestamp = true
+
+	assert.NoError(t, h.HandleLog(&slog.Entry{Level: notice, Message: "custom"}))
+	assert.NoError(t, h.HandleLog(&slog.Entry{Level: slog.WarnLevel, Message: "builtin"}))
+
+	assert.Equal(t, "\033[36mTEXT-NOTICE\033[0m custom                   \n"+
+		"\033[33m WARN\033[0m builtin                  \n", buf.String())
+}
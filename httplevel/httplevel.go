@@ -0,0 +1,119 @@
+// Package httplevel implements an http.Handler that lists the handlers
+// registered with a slog.Logger along with their maximum levels, and allows
+// those levels to be changed at runtime.
+//
+// A GET request responds with a JSON array of handlers:
+//
+//	[{"id":0,"handler":"*json.Handler","level":"info"}]
+//
+// A PUT or POST request with a JSON body of the form:
+//
+//	{"id":0,"level":"debug"}
+//
+// changes the level of the handler with that id, or of every handler if "id" is
+// omitted, and responds with the updated array. Levels are parsed with
+// slog.ParseLevelStrict.
+package httplevel
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/joshuarubin/slog"
+)
+
+// Handler implementation.
+type Handler struct {
+	logger *slog.Logger
+}
+
+// New handler.
+func New(l *slog.Logger) *Handler {
+	return &Handler{
+		logger: l,
+	}
+}
+
+type handler struct {
+	ID      uint64     `json:"id"`
+	Handler string     `json:"handler"`
+	Level   slog.Level `json:"level"`
+}
+
+type request struct {
+	ID    *uint64 `json:"id"`
+	Level string  `json:"level"`
+}
+
+// ServeHTTP implements http.Handler.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet, http.MethodHead:
+	case http.MethodPut, http.MethodPost:
+		if status, err := h.update(r); err != nil {
+			http.Error(w, err.Error(), status)
+			return
+		}
+	default:
+		w.Header().Set("Allow", "GET, HEAD, PUT, POST")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(h.list())
+}
+
+func (h *Handler) list() []handler {
+	regs := h.logger.Handlers()
+	ret := make([]handler, len(regs))
+
+	for i, r := range regs {
+		ret[i] = handler{
+			ID:      r.ID(),
+			Handler: fmt.Sprintf("%T", r.Handler()),
+			Level:   r.Level(),
+		}
+	}
+
+	return ret
+}
+
+func (h *Handler) update(r *http.Request) (int, error) {
+	var req request
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return http.StatusBadRequest, fmt.Errorf("invalid request: %s", err)
+	}
+
+	level, err := slog.ParseLevelStrict(req.Level)
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	var vars []*slog.LevelVar
+	for _, reg := range h.logger.Handlers() {
+		if req.ID != nil && *req.ID != reg.ID() {
+			continue
+		}
+
+		v := reg.LevelVar()
+		if v == nil {
+			return http.StatusConflict, slog.ErrFixedLevel
+		}
+
+		vars = append(vars, v)
+	}
+
+	if len(vars) == 0 && req.ID != nil {
+		return http.StatusNotFound, fmt.Errorf("handler not found: %d", *req.ID)
+	}
+
+	// every target is checked before any is changed so that the request
+	// either succeeds or has no effect
+	for _, v := range vars {
+		v.SetLevel(level)
+	}
+
+	return http.StatusOK, nil
+}
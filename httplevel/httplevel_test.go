@@ -0,0 +1,98 @@
+package httplevel_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/joshuarubin/slog"
+	"github.com/joshuarubin/slog/handlers/discard"
+	"github.com/joshuarubin/slog/handlers/memory"
+	"github.com/joshuarubin/slog/httplevel"
+	"github.com/stretchr/testify/assert"
+)
+
+type fixed struct{}
+
+func (fixed) Level() slog.Level { return slog.InfoLevel }
+
+func serve(h http.Handler, method, body string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(method, "/", strings.NewReader(body)))
+	return w
+}
+
+func TestHandler(t *testing.T) {
+	m := memory.New()
+
+	l := slog.New()
+	l.RegisterHandler(slog.InfoLevel, m)
+	l.RegisterHandler(slog.NewLevelVar(slog.ErrorLevel), discard.New())
+
+	h := httplevel.New(l)
+
+	{
+		w := serve(h, http.MethodGet, "")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+		assert.JSONEq(t, `[
+			{"id":0,"handler":"*memory.Handler","level":"info"},
+			{"id":1,"handler":"*discard.Handler","level":"error"}
+		]`, w.Body.String())
+	}
+
+	l.Debug("dropped")
+
+	{
+		w := serve(h, http.MethodPut, `{"id":0,"level":"debug"}`)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `[
+			{"id":0,"handler":"*memory.Handler","level":"debug"},
+			{"id":1,"handler":"*discard.Handler","level":"error"}
+		]`, w.Body.String())
+	}
+
+	l.Debug("logged")
+
+	{
+		w := serve(h, http.MethodPost, `{"level":"warn"}`)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `[
+			{"id":0,"handler":"*memory.Handler","level":"warn"},
+			{"id":1,"handler":"*discard.Handler","level":"warn"}
+		]`, w.Body.String())
+	}
+
+	l.Info("dropped")
+
+	assert.Equal(t, 1, len(m.Entries))
+	assert.Equal(t, "logged", m.Entries[0].Message)
+}
+
+func TestHandler_errors(t *testing.T) {
+	l := slog.New()
+	l.RegisterHandler(fixed{}, discard.New())
+
+	h := httplevel.New(l)
+
+	assert.Equal(t, http.StatusBadRequest, serve(h, http.MethodPut, `{`).Code)
+	assert.Equal(t, http.StatusBadRequest, serve(h, http.MethodPut, `{"level":"bogus"}`).Code)
+	assert.Equal(t, http.StatusNotFound, serve(h, http.MethodPut, `{"id":7,"level":"info"}`).Code)
+	assert.Equal(t, http.StatusConflict, serve(h, http.MethodPut, `{"id":0,"level":"info"}`).Code)
+
+	w := serve(h, http.MethodDelete, "")
+	assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+	assert.Equal(t, "GET, HEAD, PUT, POST", w.Header().Get("Allow"))
+}
+
+func TestHandler_atomic(t *testing.T) {
+	l := slog.New()
+	r := l.Register(slog.InfoLevel, discard.New())
+	l.RegisterHandler(fixed{}, discard.New())
+
+	h := httplevel.New(l)
+
+	assert.Equal(t, http.StatusConflict, serve(h, http.MethodPut, `{"level":"debug"}`).Code)
+	assert.Equal(t, slog.InfoLevel, r.Level())
+}
@@ -1,6 +1,9 @@
 package slog
 
-import "io"
+import (
+	"context"
+	"io"
+)
 
 // PrefixWriteCloser is an io.WriteCloser that can be prefixed for every line
 // it writes
@@ -13,14 +16,32 @@ type PrefixWriteCloser interface {
 type Interface interface {
 	WithFields(fields Fielder) *Entry
 	WithField(key string, value interface{}) *Entry
+	With(fields ...Field) *Entry
 	WithError(err error) *Entry
+	WithContext(ctx context.Context) *Entry
+	Log(level Level, msg string)
 	Debug(msg string)
 	Info(msg string)
 	Warn(msg string)
 	Error(msg string)
 	Fatal(msg string)
 	Panic(msg string)
+	Logf(level Level, format string, args ...interface{})
+	Debugf(format string, args ...interface{})
+	Infof(format string, args ...interface{})
+	Warnf(format string, args ...interface{})
+	Errorf(format string, args ...interface{})
+	Fatalf(format string, args ...interface{})
+	Panicf(format string, args ...interface{})
+	Logw(level Level, msg string, keysAndValues ...interface{})
+	Debugw(msg string, keysAndValues ...interface{})
+	Infow(msg string, keysAndValues ...interface{})
+	Warnw(msg string, keysAndValues ...interface{})
+	Errorw(msg string, keysAndValues ...interface{})
+	Fatalw(msg string, keysAndValues ...interface{})
+	Panicw(msg string, keysAndValues ...interface{})
 	IfError(error) Interface
+	Enabled(level Level) bool
 	Trace(level Level, msg string) *Entry
 	Writer(level Level) PrefixWriteCloser
 }
@@ -1,7 +1,13 @@
 package slog
 
 import (
+	"errors"
+	"fmt"
+	"sort"
 	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"unicode"
 	"unicode/utf8"
 )
@@ -19,32 +25,177 @@ const (
 	DebugLevel
 )
 
-var levelNames = [...]string{
-	PanicLevel: "panic",
-	FatalLevel: "fatal",
-	ErrorLevel: "error",
-	WarnLevel:  "warn",
-	InfoLevel:  "info",
-	DebugLevel: "debug",
+// levelInfo describes a Level.
+type levelInfo struct {
+	name      string
+	aliases   []string
+	verbosity int
 }
 
-// Set implements cli.Generic
-func (l *Level) Set(value string) error {
-	*l = ParseLevel(value, WarnLevel)
-	return nil
+var builtinLevels = []levelInfo{
+	PanicLevel: {name: "panic", verbosity: 0},
+	FatalLevel: {name: "fatal", aliases: []string{"crit", "critical"}, verbosity: 10},
+	ErrorLevel: {name: "error", aliases: []string{"err"}, verbosity: 20},
+	WarnLevel:  {name: "warn", aliases: []string{"warning"}, verbosity: 30},
+	InfoLevel:  {name: "info", verbosity: 40},
+	DebugLevel: {name: "debug", verbosity: 50},
 }
 
-// String implements io.Stringer.
-func (l Level) String() string {
+// levelTable holds the levelInfo of every Level, indexed by Level, once a
+// custom level has been registered. It is replaced by RegisterLevel, never
+// modified, so it can be read without locking.
+var (
+	levelTable atomic.Value // []levelInfo
+	levelMu    sync.Mutex   // serializes RegisterLevel
+)
+
+func levelInfos() []levelInfo {
+	if infos, ok := levelTable.Load().([]levelInfo); ok {
+		return infos
+	}
+
+	return builtinLevels
+}
+
+// RegisterLevel adds a new Level named `name`, which must not already be used
+// as a level name or alias. Aliases are additional names that ParseLevel
+// accepts. Names are case insensitive.
+//
+// The verbosity orders the level relative to the others, and must be unique.
+// The built-in levels have verbosities from 0 for PanicLevel to 50 for
+// DebugLevel in steps of 10, so e.g. a "trace" level more verbose than debug
+// could use 60, and a "notice" level between info and warn could use 35.
+// Handlers registered with a maximum level are passed entries with a verbosity
+// less than or equal to that of their level.
+//
+// Levels should be registered during initialization.
+func RegisterLevel(name string, verbosity int, aliases ...string) (Level, error) {
+	levelMu.Lock()
+	defer levelMu.Unlock()
+
+	infos := levelInfos()
+
+	if name == "" {
+		return 0, errors.New("slog: level name is required")
+	}
+
+	for _, n := range append([]string{name}, aliases...) {
+		if _, ok := lookupLevel(infos, n); ok {
+			return 0, fmt.Errorf("slog: level name %q is already registered", n)
+		}
+	}
+
+	for l, info := range infos {
+		if info.verbosity == verbosity {
+			return 0, fmt.Errorf("slog: level verbosity %d is already used by %s", verbosity, Level(l))
+		}
+	}
+
+	level := Level(len(infos))
+
+	lowered := make([]string, len(aliases))
+	for i, alias := range aliases {
+		lowered[i] = strings.ToLower(alias)
+	}
+
+	levelTable.Store(append(infos[:len(infos):len(infos)], levelInfo{
+		name:      strings.ToLower(name),
+		aliases:   lowered,
+		verbosity: verbosity,
+	}))
+
+	return level, nil
+}
+
+// MustRegisterLevel is like RegisterLevel but panics if the level can not be
+// registered. It simplifies declaring custom levels as package variables.
+func MustRegisterLevel(name string, verbosity int, aliases ...string) Level {
+	level, err := RegisterLevel(name, verbosity, aliases...)
+	if err != nil {
+		panic(err)
+	}
+
+	return level
+}
+
+// Levels returns every registered Level, from least to most verbose.
+func Levels() []Level {
+	infos := levelInfos()
+
+	ret := make([]Level, len(infos))
+	for i := range infos {
+		ret[i] = Level(i)
+	}
+
+	sort.Slice(ret, func(i, j int) bool {
+		return infos[ret[i]].verbosity < infos[ret[j]].verbosity
+	})
+
+	return ret
+}
+
+// lookupLevel returns the Level with the case insensitive name or alias.
+func lookupLevel(infos []levelInfo, name string) (Level, bool) {
+	name = strings.ToLower(name)
+
+	for l, info := range infos {
+		if info.name == name {
+			return Level(l), true
+		}
+
+		for _, alias := range info.aliases {
+			if alias == name {
+				return Level(l), true
+			}
+		}
+	}
+
+	return 0, false
+}
+
+// info returns the registered level info for l. Levels that aren't registered
+// are treated as PanicLevel if they are negative and DebugLevel otherwise.
+func (l Level) info() levelInfo {
+	infos := levelInfos()
+
 	if l < PanicLevel {
 		l = PanicLevel
 	}
 
-	if l > DebugLevel {
+	if int(l) >= len(infos) {
 		l = DebugLevel
 	}
 
-	return levelNames[l]
+	return infos[l]
+}
+
+// Verbosity returns the level's position in the ordering of levels. See
+// RegisterLevel.
+func (l Level) Verbosity() int {
+	return l.info().verbosity
+}
+
+// levelEnabled reports whether an entry at level should be passed to a handler
+// registered with maxLevel.
+func levelEnabled(level, maxLevel Level) bool {
+	return level.Verbosity() <= maxLevel.Verbosity()
+}
+
+// Set implements flag.Value and cli.Generic. It uses ParseLevelStrict and
+// leaves the level unchanged if value is invalid.
+func (l *Level) Set(value string) error {
+	level, err := ParseLevelStrict(value)
+	if err != nil {
+		return err
+	}
+
+	*l = level
+	return nil
+}
+
+// String implements io.Stringer.
+func (l Level) String() string {
+	return l.info().name
 }
 
 // MarshalJSON returns the level string.
@@ -57,18 +208,53 @@ func (l Level) MarshalText() ([]byte, error) {
 	return []byte(l.String()), nil
 }
 
-// UnmarshalText implements encoding.TextUnmarshaler
+// UnmarshalText implements encoding.TextUnmarshaler. It uses ParseLevelStrict
+// and leaves the level unchanged if text is invalid.
 func (l *Level) UnmarshalText(text []byte) error {
-	*l = ParseLevel(string(text), WarnLevel)
-	return nil
+	return l.Set(string(text))
+}
+
+// ParseLevelStrict parses a level name or alias, case insensitively, or the
+// number of a registered level. Unlike ParseLevel, it returns an error for any
+// other input, listing the valid level names.
+func ParseLevelStrict(s string) (Level, error) {
+	s = strings.TrimSpace(s)
+	infos := levelInfos()
+
+	if i, err := strconv.Atoi(s); err == nil {
+		if i >= 0 && i < len(infos) {
+			return Level(i), nil
+		}
+	} else if l, ok := lookupLevel(infos, s); ok {
+		return l, nil
+	}
+
+	return 0, fmt.Errorf("slog: invalid level %q, must be one of: %s", s, levelNameList())
 }
 
-// ParseLevel parses level string.
+// levelNameList returns a comma separated list of the level names, from least
+// to most verbose.
+func levelNameList() string {
+	levels := Levels()
+
+	names := make([]string, len(levels))
+	for i, l := range levels {
+		names[i] = l.String()
+	}
+
+	return strings.Join(names, ", ")
+}
+
+// ParseLevel parses level string. Registered level names and aliases are
+// matched case insensitively, otherwise the built-in level starting with the
+// same letter is used.
 func ParseLevel(s string, defaultLevel Level) Level {
 	if len(s) == 0 {
 		return defaultLevel
 	}
 
+	infos := levelInfos()
+
 	if i, err := strconv.Atoi(s); err == nil {
 		l := Level(i)
 
@@ -76,13 +262,17 @@ func ParseLevel(s string, defaultLevel Level) Level {
 			l = PanicLevel
 		}
 
-		if l > DebugLevel {
+		if int(l) >= len(infos) {
 			l = DebugLevel
 		}
 
 		return l
 	}
 
+	if l, ok := lookupLevel(infos, s); ok {
+		return l
+	}
+
 	r, _ := utf8.DecodeRuneInString(s)
 	r = unicode.ToLower(r)
 
@@ -2,6 +2,8 @@ package slog
 
 import (
 	"encoding/json"
+	"os"
+	"strconv"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -37,3 +39,113 @@ func TestLevel_MarshalJSON(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, expect, string(b))
 }
+
+// custom levels used by the tests. Levels can't be unregistered, so they are
+// registered once, by TestMain, and every test in the package sees them.
+var traceLevel, noticeLevel Level
+
+func TestMain(m *testing.M) {
+	traceLevel = MustRegisterLevel("trace", 60, "trc")
+	noticeLevel = MustRegisterLevel("Notice", 35)
+
+	os.Exit(m.Run())
+}
+
+func TestRegisterLevel(t *testing.T) {
+	assert.Equal(t, "trace", traceLevel.String())
+	assert.Equal(t, "notice", noticeLevel.String())
+
+	assert.Equal(t, traceLevel, ParseLevel("TRACE", WarnLevel))
+	assert.Equal(t, traceLevel, ParseLevel("trc", WarnLevel))
+	assert.Equal(t, noticeLevel, ParseLevel("notice", WarnLevel))
+	assert.Equal(t, noticeLevel, ParseLevel(strconv.Itoa(int(noticeLevel)), WarnLevel))
+
+	assert.Equal(t, []Level{
+		PanicLevel, FatalLevel, ErrorLevel, WarnLevel, noticeLevel, InfoLevel, DebugLevel, traceLevel,
+	}, Levels())
+
+	assert.True(t, levelEnabled(noticeLevel, InfoLevel))
+	assert.False(t, levelEnabled(noticeLevel, WarnLevel))
+	assert.True(t, levelEnabled(DebugLevel, traceLevel))
+	assert.False(t, levelEnabled(traceLevel, DebugLevel))
+
+	_, err := RegisterLevel("TRACE", 70)
+	assert.Error(t, err)
+
+	_, err = RegisterLevel("verbose", 60)
+	assert.Error(t, err)
+
+	_, err = RegisterLevel("verbose", 70, "warn")
+	assert.Error(t, err)
+
+	_, err = RegisterLevel("", 70)
+	assert.Error(t, err)
+}
+
+func TestLogger_customLevel(t *testing.T) {
+	var entries []*Entry
+	h := HandlerFunc(func(e *Entry) error {
+		entries = append(entries, e)
+		return nil
+	})
+
+	l := New()
+	l.RegisterHandler(noticeLevel, h)
+
+	l.Log(traceLevel, "trace")
+	l.Info("info")
+	l.Log(noticeLevel, "notice")
+	l.Warn("warn")
+
+	w := l.Writer(noticeLevel)
+	_, _ = w.Write([]byte("written\n"))
+
+	assert.Equal(t, 3, len(entries))
+	assert.Equal(t, noticeLevel, entries[0].Level)
+	assert.Equal(t, WarnLevel, entries[1].Level)
+	assert.Equal(t, "written", entries[2].Message)
+	assert.Equal(t, noticeLevel, entries[2].Level)
+}
+
+func TestParseLevelStrict(t *testing.T) {
+	valid := map[string]Level{
+		"panic":    PanicLevel,
+		"crit":     FatalLevel,
+		"Critical": FatalLevel,
+		"err":      ErrorLevel,
+		"WARNING":  WarnLevel,
+		" info ":   InfoLevel,
+		"5":        DebugLevel,
+		"trc":      traceLevel,
+	}
+
+	for s, expect := range valid {
+		level, err := ParseLevelStrict(s)
+		assert.NoError(t, err, s)
+		assert.Equal(t, expect, level, s)
+	}
+
+	for _, s := range []string{"", "wat", "wombat", "warnings", "-1", "100"} {
+		_, err := ParseLevelStrict(s)
+		assert.Error(t, err, s)
+	}
+
+	_, err := ParseLevelStrict("wat")
+	assert.EqualError(t, err, `slog: invalid level "wat", must be one of: panic, fatal, error, warn, notice, info, debug, trace`)
+}
+
+func TestLevel_Set(t *testing.T) {
+	level := InfoLevel
+
+	assert.NoError(t, level.Set("warning"))
+	assert.Equal(t, WarnLevel, level)
+
+	assert.Error(t, level.Set("wombat"))
+	assert.Equal(t, WarnLevel, level)
+
+	assert.Error(t, json.Unmarshal([]byte(`"wat"`), &level))
+	assert.Equal(t, WarnLevel, level)
+
+	assert.NoError(t, json.Unmarshal([]byte(`"debug"`), &level))
+	assert.Equal(t, DebugLevel, level)
+}
@@ -0,0 +1,76 @@
+package slog
+
+import "sync/atomic"
+
+// Leveler provides a Level. Both Level and *LevelVar implement it so either can
+// be passed to RegisterHandler.
+type Leveler interface {
+	Level() Level
+}
+
+// Level implements Leveler.
+func (l Level) Level() Level {
+	return l
+}
+
+// assert interface compliance.
+var (
+	_ Leveler = PanicLevel
+	_ Leveler = (*LevelVar)(nil)
+)
+
+// LevelVar is a Level that can be safely changed while it is being used to log,
+// e.g. to temporarily raise a handler to DebugLevel without a restart. The zero
+// value is PanicLevel.
+type LevelVar struct {
+	level int64
+}
+
+// NewLevelVar returns a new LevelVar set to level.
+func NewLevelVar(level Level) *LevelVar {
+	v := &LevelVar{}
+	v.SetLevel(level)
+	return v
+}
+
+// Level implements Leveler.
+func (v *LevelVar) Level() Level {
+	return Level(atomic.LoadInt64(&v.level))
+}
+
+// SetLevel changes the level.
+func (v *LevelVar) SetLevel(level Level) {
+	atomic.StoreInt64(&v.level, int64(level))
+}
+
+// String implements io.Stringer.
+func (v *LevelVar) String() string {
+	return v.Level().String()
+}
+
+// Set implements flag.Value and cli.Generic
+func (v *LevelVar) Set(value string) error {
+	var level Level
+	if err := level.Set(value); err != nil {
+		return err
+	}
+
+	v.SetLevel(level)
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler
+func (v *LevelVar) MarshalText() ([]byte, error) {
+	return v.Level().MarshalText()
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler
+func (v *LevelVar) UnmarshalText(text []byte) error {
+	var level Level
+	if err := level.UnmarshalText(text); err != nil {
+		return err
+	}
+
+	v.SetLevel(level)
+	return nil
+}
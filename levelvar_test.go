@@ -0,0 +1,58 @@
+package slog_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/joshuarubin/slog"
+	"github.com/joshuarubin/slog/handlers/discard"
+	"github.com/joshuarubin/slog/handlers/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLevelVar(t *testing.T) {
+	h := memory.New()
+	v := slog.NewLevelVar(slog.InfoLevel)
+
+	l := slog.New()
+	l.RegisterHandler(v, h)
+
+	l.Debug("dropped")
+	l.Info("logged")
+
+	v.SetLevel(slog.DebugLevel)
+	l.Debug("debug")
+
+	assert.NoError(t, v.Set("error"))
+	l.Warn("dropped")
+
+	assert.Equal(t, slog.ErrorLevel, v.Level())
+	assert.Equal(t, "error", v.String())
+	assert.Equal(t, 2, len(h.Entries))
+	assert.Equal(t, "logged", h.Entries[0].Message)
+	assert.Equal(t, "debug", h.Entries[1].Message)
+}
+
+func TestLevelVar_concurrent(t *testing.T) {
+	v := slog.NewLevelVar(slog.InfoLevel)
+
+	l := slog.New()
+	l.RegisterHandler(v, discard.New())
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				l.Debug("debug")
+			}
+		}()
+	}
+
+	for j := 0; j < 1000; j++ {
+		v.SetLevel(slog.Level(j % 6))
+	}
+
+	wg.Wait()
+}
@@ -1,6 +1,12 @@
 package slog
 
-import "log"
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+)
 
 // assert interface compliance.
 var _ Interface = (*Logger)(nil)
@@ -13,6 +19,11 @@ type Fielder interface {
 // Fields represents a map of entry level data used for structured logging.
 type Fields map[string]interface{}
 
+// LazyValue is a field value that is only computed if the entry it was added
+// to is passed to a handler. Values of type func() interface{} are treated the
+// same way.
+type LazyValue func() interface{}
+
 // Fields implements Fielder.
 func (f Fields) Fields() Fields {
 	return f
@@ -32,6 +43,9 @@ func (f HandlerFunc) HandleLog(e *Entry) error {
 // stdio or sending them to remote services. See the "handlers"
 // directory for implementations.
 //
+// Handlers should read fields with Entry.AllFields, which has them in the order
+// they were added and keeps their types, rather than the Entry.Fields map.
+//
 // It is left up to Handlers to implement thread-safety.
 type Handler interface {
 	HandleLog(*Entry) error
@@ -40,7 +54,70 @@ type Handler interface {
 // Logger represents a logger. It will not do anything useful unless a handler
 // has been registered with RegisterHandler.
 type Logger struct {
-	handlers [len(levelNames)][]Handler
+	mu           sync.Mutex   // serializes changes to handlers
+	handlers     atomic.Value // []*Registration, replaced, never modified
+	nextID       uint64
+	reportCaller bool
+	callerSkip   int
+	stackTrace   map[Level]bool
+	duplicates   DuplicatePolicy
+	exitFunc     func(code int)
+	panicFunc    func(v interface{})
+
+	errorHandler  ErrorHandler
+	errorLimit    int
+	errorInterval time.Duration
+	errorNow      func() time.Time // times error intervals, time.Now if nil
+	errorMu       sync.Mutex       // guards errorStart and errorCount
+	errorStart    time.Time
+	errorCount    int
+}
+
+// ErrFixedLevel is returned when changing the level of a Handler that was
+// registered with a Leveler that can not be changed.
+var ErrFixedLevel = errors.New("slog: handler level can not be changed")
+
+// Registration is a Handler registered with a Logger.
+type Registration struct {
+	errors  uint64 // accessed atomically, first for alignment
+	id      uint64
+	level   Leveler
+	handler Handler
+}
+
+// ID returns an identifier for the registration that is unique to its Logger.
+func (r *Registration) ID() uint64 {
+	return r.id
+}
+
+// Handler returns the registered Handler.
+func (r *Registration) Handler() Handler {
+	return r.handler
+}
+
+// Level returns the maximum Level that the handler is passed log entries for.
+func (r *Registration) Level() Level {
+	return r.level.Level()
+}
+
+// LevelVar returns the *LevelVar that holds the maximum Level of the handler,
+// or nil if the handler was registered with a Leveler that can not be changed.
+func (r *Registration) LevelVar() *LevelVar {
+	v, _ := r.level.(*LevelVar)
+	return v
+}
+
+// SetLevel changes the maximum Level that the handler is passed log entries
+// for. It returns ErrFixedLevel if the handler was registered with a Leveler
+// other than a Level or *LevelVar.
+func (r *Registration) SetLevel(level Level) error {
+	v := r.LevelVar()
+	if v == nil {
+		return ErrFixedLevel
+	}
+
+	v.SetLevel(level)
+	return nil
 }
 
 // New allocates a new Logger.
@@ -49,26 +126,91 @@ func New() *Logger {
 }
 
 // RegisterHandler adds a new Handler and specifies the maximum Level that the
-// handler will be passed log entries for
-func (l *Logger) RegisterHandler(maxLevel Level, handler Handler) *Logger {
-	if maxLevel < PanicLevel {
-		maxLevel = PanicLevel
+// handler will be passed log entries for. Pass a *LevelVar to be able to change
+// the level from elsewhere later.
+func (l *Logger) RegisterHandler(maxLevel Leveler, handler Handler) *Logger {
+	l.Register(maxLevel, handler)
+	return l
+}
+
+// Register is like RegisterHandler but returns the Registration so that the
+// handler can later be passed to Unregister or Replace. It is safe to call
+// while other goroutines are logging.
+func (l *Logger) Register(maxLevel Leveler, handler Handler) *Registration {
+	if level, ok := maxLevel.(Level); ok {
+		maxLevel = NewLevelVar(level)
 	}
 
-	if maxLevel > DebugLevel {
-		maxLevel = DebugLevel
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	r := l.newRegistration(maxLevel, handler)
+	l.handlers.Store(append(l.Handlers(), r))
+
+	return r
+}
+
+// Unregister removes a handler returned by Register. It reports whether the
+// handler was registered. It is safe to call while other goroutines are
+// logging, though they may still pass an entry to the handler while Unregister
+// is running.
+func (l *Logger) Unregister(r *Registration) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	handlers := l.Handlers()
+	for i, v := range handlers {
+		if v == r {
+			l.handlers.Store(append(handlers[:i], handlers[i+1:]...))
+			return true
+		}
 	}
 
-	for level := PanicLevel; level <= maxLevel; level++ {
-		if handlers := l.handlers[level]; handlers != nil {
-			l.handlers[level] = append(handlers, handler)
-			continue
+	return false
+}
+
+// Replace atomically swaps the handler registered as r for handler, keeping its
+// level and position. It returns the new Registration, or nil if r was not
+// registered. It is safe to call while other goroutines are logging.
+func (l *Logger) Replace(r *Registration, handler Handler) *Registration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	handlers := l.Handlers()
+	for i, v := range handlers {
+		if v == r {
+			handlers[i] = l.newRegistration(r.level, handler)
+			l.handlers.Store(handlers)
+			return handlers[i]
 		}
+	}
+
+	return nil
+}
 
-		l.handlers[level] = []Handler{handler}
+// newRegistration must be called with l.mu held.
+func (l *Logger) newRegistration(maxLevel Leveler, handler Handler) *Registration {
+	r := &Registration{
+		id:      l.nextID,
+		level:   maxLevel,
+		handler: handler,
 	}
 
-	return l
+	l.nextID++
+
+	return r
+}
+
+// Handlers returns a copy of the registered handlers in the order they were
+// registered.
+func (l *Logger) Handlers() []*Registration {
+	return append([]*Registration(nil), l.registrations()...)
+}
+
+// registrations returns the current handlers, which must not be modified.
+func (l *Logger) registrations() []*Registration {
+	handlers, _ := l.handlers.Load().([]*Registration)
+	return handlers
 }
 
 // WithFields returns a new entry with `fields` set.
@@ -76,16 +218,33 @@ func (l *Logger) WithFields(fields Fielder) *Entry {
 	return NewEntry(l).WithFields(fields.Fields())
 }
 
+// With returns a new entry with the typed `fields` added.
+func (l *Logger) With(fields ...Field) *Entry {
+	return NewEntry(l).With(fields...)
+}
+
 // WithField returns a new entry with the `key` and `value` set.
 func (l *Logger) WithField(key string, value interface{}) *Entry {
 	return NewEntry(l).WithField(key, value)
 }
 
+// WithContext returns a new entry that carries `ctx`.
+func (l *Logger) WithContext(ctx context.Context) *Entry {
+	return NewEntry(l).WithContext(ctx)
+}
+
 // WithError returns a new entry with the "error" set to `err`.
 func (l *Logger) WithError(err error) *Entry {
 	return NewEntry(l).WithError(err)
 }
 
+// Log a message at level, which may be a custom level registered with
+// RegisterLevel. Unlike Fatal and Panic, logging at FatalLevel or PanicLevel
+// does not exit or panic.
+func (l *Logger) Log(level Level, msg string) {
+	NewEntry(l).Log(level, msg)
+}
+
 // Debug level message.
 func (l *Logger) Debug(msg string) {
 	NewEntry(l).Debug(msg)
@@ -106,12 +265,12 @@ func (l *Logger) Error(msg string) {
 	NewEntry(l).Error(msg)
 }
 
-// Fatal level message, followed by an exit.
+// Fatal level message, followed by flushing the handlers and an exit.
 func (l *Logger) Fatal(msg string) {
 	NewEntry(l).Fatal(msg)
 }
 
-// Panic level message, followed by a panic.
+// Panic level message, followed by flushing the handlers and a panic.
 func (l *Logger) Panic(msg string) {
 	NewEntry(l).Panic(msg)
 }
@@ -131,21 +290,70 @@ func (l *Logger) Trace(level Level, msg string) *Entry {
 	return NewEntry(l).Trace(level, msg)
 }
 
-// log the message, invoking the handler. We clone the entry here
+// Enabled reports whether any registered handler accepts entries at level. It
+// can be used to skip expensive work that is only needed for logging.
+func (l *Logger) Enabled(level Level) bool {
+	for _, r := range l.registrations() {
+		if levelEnabled(level, r.level.Level()) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// log the message, invoking the handlers. We clone the entry here
 // to bypass the overhead in Entry methods when the level is not
-// met.
-func (l *Logger) log(level Level, e *Entry, msg string) {
-	handlers := l.handlers[level]
-	if len(handlers) == 0 {
-		return
+// met. The clone is returned, or nil if no handler accepted the level.
+func (l *Logger) log(level Level, e *Entry, msg string) *Entry {
+	return l.dispatch(level, func() *Entry {
+		return l.finalize(level, e, msg)
+	})
+}
+
+// dispatch passes the entry returned by build to the handlers that accept
+// level. build is only called, once, if there is such a handler, so that any
+// expensive work it does is skipped otherwise. The built entry is returned, or
+// nil if it was not built.
+func (l *Logger) dispatch(level Level, build func() *Entry) *Entry {
+	var f *Entry
+
+	for _, r := range l.registrations() {
+		if !levelEnabled(level, r.level.Level()) {
+			continue
+		}
+
+		if f == nil {
+			f = build()
+		}
+
+		if err := r.handler.HandleLog(f); err != nil {
+			l.handleError(r, f, err)
+		}
 	}
 
+	return f
+}
+
+// finalize returns a copy of the Entry with Fields merged and, if enabled, the
+// Caller and Stack captured.
+func (l *Logger) finalize(level Level, e *Entry, msg string) *Entry {
+	pc := e.pc
 	e = e.finalize(level, msg)
-	for _, h := range handlers {
-		if err := h.HandleLog(e); err != nil {
-			log.Printf("error logging: %s", err)
-		}
+
+	switch {
+	case !l.reportCaller:
+	case pc != 0:
+		e.Caller, e.pc = pcCaller(pc), pc
+	default:
+		e.Caller, e.pc = caller(l.callerSkip)
+	}
+
+	if l.stackTrace[level] {
+		e.Stack = stack(e.FieldList, l.callerSkip)
 	}
+
+	return e
 }
 
 // Nil logger that satisfies zlog.Interface but sends all messages to the bit
@@ -209,6 +209,101 @@ func TestLogger_HandlerFunc(t *testing.T) {
 	assert.Equal(t, e.Level, slog.InfoLevel)
 }
 
+func TestLogger_Unregister(t *testing.T) {
+	a, b := memory.New(), memory.New()
+
+	l := slog.New()
+	ra := l.Register(slog.InfoLevel, a)
+	l.RegisterHandler(slog.InfoLevel, b)
+
+	l.Info("both")
+
+	assert.True(t, l.Unregister(ra))
+	assert.False(t, l.Unregister(ra))
+	assert.Equal(t, 1, len(l.Handlers()))
+
+	l.Info("b only")
+
+	assert.Equal(t, 1, len(a.Entries))
+	assert.Equal(t, 2, len(b.Entries))
+}
+
+func TestLogger_Replace(t *testing.T) {
+	a, b := memory.New(), memory.New()
+
+	l := slog.New()
+	ra := l.Register(slog.InfoLevel, a)
+
+	l.Info("a")
+
+	rb := l.Replace(ra, b)
+	assert.NotNil(t, rb)
+	assert.Nil(t, l.Replace(ra, b))
+	assert.Equal(t, []*slog.Registration{rb}, l.Handlers())
+	assert.Equal(t, slog.InfoLevel, rb.Level())
+
+	l.Debug("dropped")
+	l.Info("b")
+
+	assert.Equal(t, 1, len(a.Entries))
+	assert.Equal(t, 1, len(b.Entries))
+	assert.Equal(t, "b", b.Entries[0].Message)
+}
+
+func TestLogger_Register_concurrent(t *testing.T) {
+	l := slog.New()
+	l.RegisterHandler(slog.InfoLevel, discard.New())
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 1000; i++ {
+			l.Info("login")
+		}
+	}()
+
+	for i := 0; i < 100; i++ {
+		r := l.Register(slog.InfoLevel, memory.New())
+		r = l.Replace(r, discard.New())
+		l.Unregister(r)
+	}
+
+	<-done
+}
+
+func TestLogger_Enabled(t *testing.T) {
+	l := slog.New()
+	assert.False(t, l.Enabled(slog.PanicLevel))
+
+	l.RegisterHandler(slog.InfoLevel, memory.New())
+
+	assert.True(t, l.Enabled(slog.ErrorLevel))
+	assert.True(t, l.Enabled(slog.InfoLevel))
+	assert.False(t, l.Enabled(slog.DebugLevel))
+	assert.False(t, l.WithField("file", "sloth.png").Enabled(slog.DebugLevel))
+	assert.False(t, slog.Nil.Enabled(slog.PanicLevel))
+}
+
+func TestLogger_LazyValue(t *testing.T) {
+	h := memory.New()
+
+	l := slog.New()
+	l.RegisterHandler(slog.InfoLevel, h)
+
+	var calls int
+	lazy := slog.LazyValue(func() interface{} {
+		calls++
+		return "expensive"
+	})
+
+	ctx := l.WithField("value", lazy)
+	ctx.Debug("skipped")
+	ctx.Info("logged")
+
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, slog.Fields{"value": "expensive"}, h.Entries[0].Fields)
+}
+
 func BenchmarkLogger_small(b *testing.B) {
 	l := slog.New()
 	l.RegisterHandler(slog.InfoLevel, discard.New())
@@ -0,0 +1,139 @@
+package slog
+
+// Middleware wraps a Handler to add behavior such as filtering, enrichment or
+// redaction. Middleware must not modify the *Entry it is passed as other
+// handlers receive the same one, it should pass a modified copy instead.
+type Middleware func(Handler) Handler
+
+// Chain is a list of Middleware. The first Middleware is the outermost, so it
+// sees each entry first.
+type Chain []Middleware
+
+// NewChain returns a Chain of the Middleware.
+func NewChain(mw ...Middleware) Chain {
+	return append(Chain(nil), mw...)
+}
+
+// Append returns a new Chain with mw added to the end of c.
+func (c Chain) Append(mw ...Middleware) Chain {
+	return append(c[:len(c):len(c)], mw...)
+}
+
+// Then returns h wrapped by every Middleware in the Chain.
+func (c Chain) Then(h Handler) Handler {
+	for i := len(c) - 1; i >= 0; i-- {
+		h = c[i](h)
+	}
+
+	return h
+}
+
+// WrapHandler returns a Handler that calls fn with each entry and next. It
+// implements Flusher and Closer by forwarding to next, which makes it useful
+// for writing Middleware.
+func WrapHandler(next Handler, fn func(next Handler, e *Entry) error) Handler {
+	return &wrapper{
+		next:   next,
+		handle: fn,
+	}
+}
+
+type wrapper struct {
+	next   Handler
+	handle func(next Handler, e *Entry) error
+}
+
+// HandleLog implements Handler.
+func (w *wrapper) HandleLog(e *Entry) error {
+	return w.handle(w.next, e)
+}
+
+// Flush implements Flusher.
+func (w *wrapper) Flush() error {
+	return FlushHandler(w.next)
+}
+
+// Close implements Closer.
+func (w *wrapper) Close() error {
+	return CloseHandler(w.next)
+}
+
+// hasField reports whether list has a field named key.
+func hasField(list []Field, key string) bool {
+	for _, f := range list {
+		if f.Key == key {
+			return true
+		}
+	}
+
+	return false
+}
+
+// StaticFields returns Middleware that adds fields to every entry. Fields
+// already set on an entry take precedence.
+func StaticFields(fields Fielder) Middleware {
+	static := mapFields(fields.Fields())
+
+	return func(next Handler) Handler {
+		return WrapHandler(next, func(next Handler, e *Entry) error {
+			fields := e.AllFields()
+			list := make([]Field, len(fields), len(fields)+len(static))
+			copy(list, fields)
+
+			for _, f := range static {
+				if !hasField(fields, f.Key) {
+					list = append(list, f)
+				}
+			}
+
+			return next.HandleLog(e.WithFieldList(list))
+		})
+	}
+}
+
+// RenameKeys returns Middleware that renames fields, from the keys of names to
+// their values.
+func RenameKeys(names map[string]string) Middleware {
+	return func(next Handler) Handler {
+		return WrapHandler(next, func(next Handler, e *Entry) error {
+			fields := e.AllFields()
+			list := make([]Field, len(fields))
+
+			for i, f := range fields {
+				if to, ok := names[f.Key]; ok {
+					f.Key = to
+				}
+
+				list[i] = f
+			}
+
+			return next.HandleLog(e.WithFieldList(list))
+		})
+	}
+}
+
+// Filter returns Middleware that only passes entries for which fn returns
+// true.
+func Filter(fn func(*Entry) bool) Middleware {
+	return func(next Handler) Handler {
+		return WrapHandler(next, func(next Handler, e *Entry) error {
+			if !fn(e) {
+				return nil
+			}
+
+			return next.HandleLog(e)
+		})
+	}
+}
+
+// TransformMessage returns Middleware that replaces the message of every entry
+// with the result of fn.
+func TransformMessage(fn func(string) string) Middleware {
+	return func(next Handler) Handler {
+		return WrapHandler(next, func(next Handler, e *Entry) error {
+			c := *e
+			c.Message = fn(e.Message)
+			return next.HandleLog(&c)
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package slog_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/joshuarubin/slog"
+	"github.com/joshuarubin/slog/handlers/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChain(t *testing.T) {
+	m, raw := memory.New(), memory.New()
+
+	var order []string
+	trace := func(name string) slog.Middleware {
+		return func(next slog.Handler) slog.Handler {
+			return slog.WrapHandler(next, func(next slog.Handler, e *slog.Entry) error {
+				order = append(order, name)
+				return next.HandleLog(e)
+			})
+		}
+	}
+
+	chain := slog.NewChain(trace("a"), trace("b")).Append(
+		slog.Filter(func(e *slog.Entry) bool { return e.Fields["skip"] == nil }),
+		slog.StaticFields(slog.Fields{"app": "myapp", "user": "static"}),
+		slog.RenameKeys(map[string]string{"user": "username"}),
+		slog.TransformMessage(strings.ToUpper),
+	)
+
+	l := slog.New()
+	l.RegisterHandler(slog.InfoLevel, chain.Then(m))
+	l.RegisterHandler(slog.InfoLevel, raw)
+
+	l.WithField("user", "Tobi").Info("logged in")
+	l.WithField("skip", true).Info("skipped")
+
+	assert.Equal(t, []string{"a", "b", "a", "b"}, order)
+	assert.Equal(t, 1, len(m.Entries))
+	assert.Equal(t, "LOGGED IN", m.Entries[0].Message)
+	assert.Equal(t, slog.Fields{"app": "myapp", "username": "Tobi"}, m.Entries[0].Fields)
+
+	assert.Equal(t, 2, len(raw.Entries))
+	assert.Equal(t, "logged in", raw.Entries[0].Message)
+	assert.Equal(t, slog.Fields{"user": "Tobi"}, raw.Entries[0].Fields)
+}
+
+func TestWrapHandler_Flush(t *testing.T) {
+	f := &flusher{Handler: memory.New()}
+	h := slog.NewChain(slog.TransformMessage(strings.ToUpper)).Then(f)
+
+	assert.NoError(t, h.(slog.Flusher).Flush())
+	assert.NoError(t, h.(slog.Closer).Close())
+	assert.Equal(t, 2, f.flushed)
+}
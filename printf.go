@@ -0,0 +1,200 @@
+package slog
+
+import "fmt"
+
+// badKey is the field key used for arguments to the key/value methods (e.g.
+// Infow) that are not a string key followed by a value.
+const badKey = "!BADKEY"
+
+// kvFields converts alternating keys and values to fields, in order. Any
+// argument that is not a string followed by a value is added as the value of
+// badKey rather than being dropped. Each bad argument after the first has a
+// numbered key (e.g. "!BADKEY_1") so that none of them are lost as
+// duplicates.
+func kvFields(kvs []interface{}) []Field {
+	f := make([]Field, 0, (len(kvs)+1)/2)
+	bad := 0
+
+	for i := 0; i < len(kvs); {
+		if key, ok := kvs[i].(string); ok && i+1 < len(kvs) {
+			f = append(f, Any(key, kvs[i+1]))
+			i += 2
+			continue
+		}
+
+		key := badKey
+		if bad > 0 {
+			key = fmt.Sprintf("%s_%d", badKey, bad)
+		}
+
+		f = append(f, Any(key, kvs[i]))
+		bad++
+		i++
+	}
+
+	return f
+}
+
+// logf formats the message only once a handler has accepted the level.
+func (e *Entry) logf(level Level, format string, args []interface{}) {
+	e.Logger.dispatch(level, func() *Entry {
+		return e.Logger.finalize(level, e, fmt.Sprintf(format, args...))
+	})
+}
+
+// logw adds the fields only once a handler has accepted the level.
+func (e *Entry) logw(level Level, msg string, kvs []interface{}) {
+	e.Logger.dispatch(level, func() *Entry {
+		return e.Logger.finalize(level, e.With(kvFields(kvs)...), msg)
+	})
+}
+
+// Logf logs a formatted message at level. See Log.
+func (e *Entry) Logf(level Level, format string, args ...interface{}) {
+	e.logf(level, format, args)
+}
+
+// Logw logs a message at level with alternating keys and values added as
+// fields. See Log.
+func (e *Entry) Logw(level Level, msg string, keysAndValues ...interface{}) {
+	e.logw(level, msg, keysAndValues)
+}
+
+// Debugf level formatted message.
+func (e *Entry) Debugf(format string, args ...interface{}) {
+	e.logf(DebugLevel, format, args)
+}
+
+// Infof level formatted message.
+func (e *Entry) Infof(format string, args ...interface{}) {
+	e.logf(InfoLevel, format, args)
+}
+
+// Warnf level formatted message.
+func (e *Entry) Warnf(format string, args ...interface{}) {
+	e.logf(WarnLevel, format, args)
+}
+
+// Errorf level formatted message.
+func (e *Entry) Errorf(format string, args ...interface{}) {
+	e.logf(ErrorLevel, format, args)
+}
+
+// Fatalf level formatted message, followed by flushing the handlers and an
+// exit.
+func (e *Entry) Fatalf(format string, args ...interface{}) {
+	e.Fatal(fmt.Sprintf(format, args...))
+}
+
+// Panicf level formatted message, followed by flushing the handlers and a
+// panic.
+func (e *Entry) Panicf(format string, args ...interface{}) {
+	e.Panic(fmt.Sprintf(format, args...))
+}
+
+// Debugw level message with alternating keys and values added as fields.
+func (e *Entry) Debugw(msg string, keysAndValues ...interface{}) {
+	e.logw(DebugLevel, msg, keysAndValues)
+}
+
+// Infow level message with alternating keys and values added as fields.
+func (e *Entry) Infow(msg string, keysAndValues ...interface{}) {
+	e.logw(InfoLevel, msg, keysAndValues)
+}
+
+// Warnw level message with alternating keys and values added as fields.
+func (e *Entry) Warnw(msg string, keysAndValues ...interface{}) {
+	e.logw(WarnLevel, msg, keysAndValues)
+}
+
+// Errorw level message with alternating keys and values added as fields.
+func (e *Entry) Errorw(msg string, keysAndValues ...interface{}) {
+	e.logw(ErrorLevel, msg, keysAndValues)
+}
+
+// Fatalw level message with alternating keys and values added as fields,
+// followed by flushing the handlers and an exit.
+func (e *Entry) Fatalw(msg string, keysAndValues ...interface{}) {
+	e.With(kvFields(keysAndValues)...).Fatal(msg)
+}
+
+// Panicw level message with alternating keys and values added as fields,
+// followed by flushing the handlers and a panic.
+func (e *Entry) Panicw(msg string, keysAndValues ...interface{}) {
+	e.With(kvFields(keysAndValues)...).Panic(msg)
+}
+
+// Logf logs a formatted message at level. See Log.
+func (l *Logger) Logf(level Level, format string, args ...interface{}) {
+	NewEntry(l).logf(level, format, args)
+}
+
+// Logw logs a message at level with alternating keys and values added as
+// fields. See Log.
+func (l *Logger) Logw(level Level, msg string, keysAndValues ...interface{}) {
+	NewEntry(l).logw(level, msg, keysAndValues)
+}
+
+// Debugf level formatted message.
+func (l *Logger) Debugf(format string, args ...interface{}) {
+	NewEntry(l).logf(DebugLevel, format, args)
+}
+
+// Infof level formatted message.
+func (l *Logger) Infof(format string, args ...interface{}) {
+	NewEntry(l).logf(InfoLevel, format, args)
+}
+
+// Warnf level formatted message.
+func (l *Logger) Warnf(format string, args ...interface{}) {
+	NewEntry(l).logf(WarnLevel, format, args)
+}
+
+// Errorf level formatted message.
+func (l *Logger) Errorf(format string, args ...interface{}) {
+	NewEntry(l).logf(ErrorLevel, format, args)
+}
+
+// Fatalf level formatted message, followed by flushing the handlers and an
+// exit.
+func (l *Logger) Fatalf(format string, args ...interface{}) {
+	NewEntry(l).Fatalf(format, args...)
+}
+
+// Panicf level formatted message, followed by flushing the handlers and a
+// panic.
+func (l *Logger) Panicf(format string, args ...interface{}) {
+	NewEntry(l).Panicf(format, args...)
+}
+
+// Debugw level message with alternating keys and values added as fields.
+func (l *Logger) Debugw(msg string, keysAndValues ...interface{}) {
+	NewEntry(l).logw(DebugLevel, msg, keysAndValues)
+}
+
+// Infow level message with alternating keys and values added as fields.
+func (l *Logger) Infow(msg string, keysAndValues ...interface{}) {
+	NewEntry(l).logw(InfoLevel, msg, keysAndValues)
+}
+
+// Warnw level message with alternating keys and values added as fields.
+func (l *Logger) Warnw(msg string, keysAndValues ...interface{}) {
+	NewEntry(l).logw(WarnLevel, msg, keysAndValues)
+}
+
+// Errorw level message with alternating keys and values added as fields.
+func (l *Logger) Errorw(msg string, keysAndValues ...interface{}) {
+	NewEntry(l).logw(ErrorLevel, msg, keysAndValues)
+}
+
+// Fatalw level message with alternating keys and values added as fields,
+// followed by flushing the handlers and an exit.
+func (l *Logger) Fatalw(msg string, keysAndValues ...interface{}) {
+	NewEntry(l).Fatalw(msg, keysAndValues...)
+}
+
+// Panicw level message with alternating keys and values added as fields,
+// followed by flushing the handlers and a panic.
+func (l *Logger) Panicw(msg string, keysAndValues ...interface{}) {
+	NewEntry(l).Panicw(msg, keysAndValues...)
+}
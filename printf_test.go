@@ -0,0 +1,67 @@
+package slog_test
+
+import (
+	"testing"
+
+	"github.com/joshuarubin/slog"
+	"github.com/joshuarubin/slog/handlers/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+type counter int
+
+func (c *counter) String() string {
+	*c++
+	return "counted"
+}
+
+func TestLogger_Infof(t *testing.T) {
+	h := memory.New()
+
+	l := slog.New()
+	l.RegisterHandler(slog.InfoLevel, h)
+
+	var c counter
+	l.Debugf("skipped %s", &c)
+	l.WithField("file", "sloth.png").Infof("logged %s %d", &c, 3)
+
+	assert.Equal(t, counter(1), c)
+	assert.Equal(t, 1, len(h.Entries))
+	assert.Equal(t, "logged counted 3", h.Entries[0].Message)
+	assert.Equal(t, slog.Fields{"file": "sloth.png"}, h.Entries[0].Fields)
+}
+
+func TestLogger_Infow(t *testing.T) {
+	h := memory.New()
+
+	l := slog.New()
+	l.RegisterHandler(slog.InfoLevel, h)
+
+	var c counter
+	l.Debugw("skipped", "counter", &c)
+	l.WithField("file", "sloth.png").Infow("upload", "user", "Tobi", "size", 3)
+	l.Warnw("odd", "user", "Tobi", "dangling")
+	l.Errorw("invalid", 42, "value")
+	l.Errorw("bad", 1, 2, 3)
+
+	assert.Equal(t, 4, len(h.Entries))
+	assert.Equal(t, slog.Fields{"file": "sloth.png", "user": "Tobi", "size": 3}, h.Entries[0].Fields)
+	assert.Equal(t, slog.Fields{"user": "Tobi", "!BADKEY": "dangling"}, h.Entries[1].Fields)
+	assert.Equal(t, slog.Fields{"!BADKEY": 42, "!BADKEY_1": "value"}, h.Entries[2].Fields)
+	assert.Equal(t, slog.Fields{"!BADKEY": 1, "!BADKEY_1": 2, "!BADKEY_2": 3}, h.Entries[3].Fields)
+}
+
+func TestLogger_Fatalf(t *testing.T) {
+	h := memory.New()
+
+	code := -1
+	l := slog.New().SetExitFunc(func(c int) { code = c })
+	l.RegisterHandler(slog.InfoLevel, h)
+
+	l.Fatalf("exit %d", 1)
+	l.Fatalw("exit", "code", 1)
+
+	assert.Equal(t, 1, code)
+	assert.Equal(t, "exit 1", h.Entries[0].Message)
+	assert.Equal(t, slog.Fields{"code": 1}, h.Entries[1].Fields)
+}
@@ -0,0 +1,130 @@
+package slog
+
+import (
+	"errors"
+	"runtime"
+	"strconv"
+	"strings"
+
+	pkgerrors "github.com/pkg/errors"
+)
+
+// maxStackDepth is the maximum number of frames captured in a Stack.
+const maxStackDepth = 64
+
+// Stack is a stack trace, the innermost frame first.
+type Stack []Caller
+
+// String returns the stack formatted similarly to the stack traces printed by
+// the runtime for panics.
+func (s Stack) String() string {
+	var b strings.Builder
+
+	for i, c := range s {
+		if i > 0 {
+			b.WriteByte('\n')
+		}
+
+		b.WriteString(c.Function)
+		b.WriteString("\n\t")
+		b.WriteString(c.File)
+		b.WriteByte(':')
+		b.WriteString(strconv.Itoa(c.Line))
+	}
+
+	return b.String()
+}
+
+// stackTracer is implemented by errors created with github.com/pkg/errors.
+type stackTracer interface {
+	StackTrace() pkgerrors.StackTrace
+}
+
+// SetStackTrace enables capturing a Stack in Entry.Stack for entries logged at
+// any of `levels`, replacing any previously enabled levels. If one of the
+// entry's fields is an error (or wraps one) that carries its own stack trace,
+// like those created with github.com/pkg/errors, that stack is used instead
+// since it shows where the failure happened. It should be called before the
+// Logger is used.
+func (l *Logger) SetStackTrace(levels ...Level) *Logger {
+	l.stackTrace = map[Level]bool{}
+
+	for _, level := range levels {
+		l.stackTrace[level] = true
+	}
+
+	return l
+}
+
+// stack returns the stack of the first error in fields that carries one,
+// falling back to the stack of the caller. The "error" field is checked first.
+func stack(fields []Field, skip int) Stack {
+	for _, f := range fields {
+		if f.Key == "error" {
+			if s := fieldStack(f); len(s) > 0 {
+				return s
+			}
+		}
+	}
+
+	for _, f := range fields {
+		if f.Key != "error" {
+			if s := fieldStack(f); len(s) > 0 {
+				return s
+			}
+		}
+	}
+
+	return callers(skip, maxStackDepth)
+}
+
+// fieldStack returns the stack of the error held by f, if any.
+func fieldStack(f Field) Stack {
+	if err, ok := f.Interface.(error); ok {
+		return errorStack(err)
+	}
+
+	return nil
+}
+
+// errorStack returns the stack trace of the innermost error in the chain that
+// carries one.
+func errorStack(err error) Stack {
+	var s Stack
+
+	for err != nil {
+		if st, ok := err.(stackTracer); ok {
+			if t := st.StackTrace(); len(t) > 0 {
+				s = framesStack(t)
+			}
+		}
+
+		if c, ok := err.(interface{ Cause() error }); ok {
+			err = c.Cause()
+			continue
+		}
+
+		err = errors.Unwrap(err)
+	}
+
+	return s
+}
+
+func framesStack(t pkgerrors.StackTrace) Stack {
+	pcs := make([]uintptr, len(t))
+	for i, f := range t {
+		pcs[i] = uintptr(f)
+	}
+
+	frames := runtime.CallersFrames(pcs)
+
+	s := make(Stack, 0, len(pcs))
+	for {
+		frame, more := frames.Next()
+		s = append(s, frameCaller(frame))
+
+		if !more {
+			return s
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package slog_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/joshuarubin/slog"
+	"github.com/joshuarubin/slog/handlers/memory"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLogger_SetStackTrace(t *testing.T) {
+	h := memory.New()
+
+	l := slog.New().SetStackTrace(slog.ErrorLevel)
+	l.RegisterHandler(slog.InfoLevel, h)
+
+	l.Info("no stack")
+	l.Error("stack")
+	expect := line() - 1
+
+	assert.Equal(t, 2, len(h.Entries))
+	assert.Nil(t, h.Entries[0].Stack)
+
+	s := h.Entries[1].Stack
+	if assert.NotEmpty(t, s) {
+		assert.Contains(t, s[0].Function, "TestLogger_SetStackTrace")
+		assert.Equal(t, expect, s[0].Line)
+	}
+}
+
+func newError() (error, int) {
+	return errors.New("boom"), line()
+}
+
+func TestLogger_SetStackTrace_error(t *testing.T) {
+	h := memory.New()
+
+	l := slog.New().SetStackTrace(slog.ErrorLevel)
+	l.RegisterHandler(slog.InfoLevel, h)
+
+	err, expect := newError()
+	l.WithError(fmt.Errorf("wrapped: %w", errors.Wrap(err, "wrapped"))).Error("failed")
+
+	s := h.Entries[0].Stack
+	if assert.NotEmpty(t, s) {
+		assert.Contains(t, s[0].Function, "newError")
+		assert.Equal(t, expect, s[0].Line)
+	}
+}
@@ -0,0 +1,81 @@
+package slog
+
+import (
+	"log"
+	"strings"
+	"unicode"
+)
+
+// RedirectStdLog makes the standard logger of the log package write each line
+// to l at level, as with RedirectLogger. It returns a function that restores
+// the previous output and flags of the standard logger.
+func (l *Logger) RedirectStdLog(level Level, parseLevel bool) (restore func()) {
+	return l.RedirectLogger(log.Default(), level, parseLevel)
+}
+
+// RedirectLogger makes std write each line to l at level. The flags of std are
+// cleared, as entries have their own time and caller. When parseLevel is true,
+// lines that start with a level name, such as "[WARN] disk full" or
+// "error: disk full", are logged at that level with the name removed.
+//
+// Lines are logged with Log, so lines at FatalLevel or PanicLevel don't exit or
+// panic themselves; log.Fatal and log.Panic still do. It returns a function
+// that restores the previous output and flags of std.
+func (l *Logger) RedirectLogger(std *log.Logger, level Level, parseLevel bool) (restore func()) {
+	e := NewEntry(l)
+
+	w := &syncWriter{
+		printFunc: func(msg string) {
+			lvl := level
+			if parseLevel {
+				lvl, msg = splitLevel(msg, level)
+			}
+
+			e.Log(lvl, msg)
+		},
+	}
+
+	out, flags := std.Writer(), std.Flags()
+	std.SetOutput(w)
+	std.SetFlags(0)
+
+	return func() {
+		std.SetOutput(out)
+		std.SetFlags(flags)
+		_ = w.Close()
+	}
+}
+
+// splitLevel returns the level named at the start of msg, as "[name] " or
+// "name: ", and the rest of msg. If msg doesn't start with a level name it
+// returns level and msg unchanged.
+func splitLevel(msg string, level Level) (Level, string) {
+	var name, rest string
+
+	if strings.HasPrefix(msg, "[") {
+		i := strings.IndexByte(msg, ']')
+		if i < 0 {
+			return level, msg
+		}
+
+		name, rest = msg[1:i], msg[i+1:]
+	} else {
+		i := strings.IndexByte(msg, ':')
+		if i < 0 {
+			return level, msg
+		}
+
+		name, rest = msg[:i], msg[i+1:]
+	}
+
+	if name == "" || strings.IndexFunc(name, func(r rune) bool { return !unicode.IsLetter(r) }) >= 0 {
+		return level, msg
+	}
+
+	l, ok := lookupLevel(levelInfos(), name)
+	if !ok {
+		return level, msg
+	}
+
+	return l, strings.TrimLeft(rest, " ")
+}
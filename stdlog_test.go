@@ -0,0 +1,76 @@
+package slog_test
+
+import (
+	"bytes"
+	"log"
+	"path/filepath"
+	"testing"
+
+	"github.com/joshuarubin/slog"
+	"github.com/joshuarubin/slog/handlers/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLogger_RedirectLogger(t *testing.T) {
+	h := memory.New()
+
+	l := slog.New().SetReportCaller(true)
+	l.RegisterHandler(slog.DebugLevel, h)
+
+	var buf bytes.Buffer
+	std := log.New(&buf, "", log.LstdFlags)
+
+	restore := l.RedirectLogger(std, slog.InfoLevel, true)
+
+	std.Print("hello")
+	expectLine := line() - 1
+	std.Print("[WARN] disk full")
+	std.Print("error: boom")
+	std.Print("Debug:verbose")
+	std.Print("[nope] unchanged")
+	std.Print("5: unchanged")
+	std.Print("two\nlines")
+
+	restore()
+	std.Print("restored")
+
+	assert.Equal(t, []string{
+		"info hello",
+		"warn disk full",
+		"error boom",
+		"debug verbose",
+		"info [nope] unchanged",
+		"info 5: unchanged",
+		"info two",
+		"info lines",
+	}, levelMessages(h.Entries))
+
+	c := h.Entries[0].Caller
+	assert.Equal(t, "stdlog_test.go", filepath.Base(c.File))
+	assert.Equal(t, expectLine, c.Line)
+
+	assert.Equal(t, log.LstdFlags, std.Flags())
+	assert.Contains(t, buf.String(), "restored")
+}
+
+func TestLogger_RedirectStdLog(t *testing.T) {
+	h := memory.New()
+
+	l := slog.New()
+	l.RegisterHandler(slog.DebugLevel, h)
+
+	restore := l.RedirectStdLog(slog.WarnLevel, false)
+	log.Print("[ERROR] not parsed")
+	restore()
+
+	assert.Equal(t, []string{"warn [ERROR] not parsed"}, levelMessages(h.Entries))
+}
+
+func levelMessages(entries []*slog.Entry) []string {
+	ret := make([]string, len(entries))
+	for i, e := range entries {
+		ret[i] = e.Level.String() + " " + e.Message
+	}
+
+	return ret
+}
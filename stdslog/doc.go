@@ -0,0 +1,11 @@
+// Package stdslog bridges slog and the log/slog package of the standard
+// library, which requires Go 1.21 or later.
+//
+// StdHandler is a log/slog.Handler that forwards records to a *slog.Logger,
+// so libraries that log with log/slog can be sent to the handlers of a Logger.
+// Handler is a slog.Handler that forwards entries to any log/slog.Handler.
+//
+// Levels are mapped between the two by ToStdLevel and FromStdLevel. Groups
+// are flattened, with the attributes of group "g" added as fields prefixed
+// with "g.".
+package stdslog
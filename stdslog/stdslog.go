@@ -0,0 +1,211 @@
+//go:build go1.21
+// +build go1.21
+
+package stdslog
+
+import (
+	"context"
+	std "log/slog"
+
+	"github.com/joshuarubin/slog"
+)
+
+// assert interface compliance.
+var (
+	_ std.Handler  = (*StdHandler)(nil)
+	_ slog.Handler = (*Handler)(nil)
+)
+
+// ToStdLevel returns the log/slog level for level. The built in levels map to
+// Debug -4, Info 0, Warn 4, Error 8, Fatal 12 and Panic 16, and custom levels
+// fall between them according to their verbosity.
+func ToStdLevel(level slog.Level) std.Level {
+	return std.Level((slog.InfoLevel.Verbosity() - level.Verbosity()) * 2 / 5)
+}
+
+// FromStdLevel returns the built in level that corresponds to level. Levels
+// between those of ToStdLevel are rounded down, so std.LevelWarn+2 is
+// slog.WarnLevel.
+func FromStdLevel(level std.Level) slog.Level {
+	switch {
+	case level < std.LevelInfo:
+		return slog.DebugLevel
+	case level < std.LevelWarn:
+		return slog.InfoLevel
+	case level < std.LevelError:
+		return slog.WarnLevel
+	case level < std.LevelError+4:
+		return slog.ErrorLevel
+	case level < std.LevelError+8:
+		return slog.FatalLevel
+	}
+
+	return slog.PanicLevel
+}
+
+// StdHandler is a log/slog.Handler that forwards records to a *slog.Logger.
+// Records are logged with Log, so those at the levels of Fatal and Panic do not
+// exit or panic. The time and source of the record are kept as the Time and,
+// if the Logger reports callers, the Caller of the entry.
+type StdHandler struct {
+	logger *slog.Logger
+	fields []slog.Field
+	prefix string
+}
+
+// NewStdHandler returns a log/slog.Handler that forwards records to l.
+func NewStdHandler(l *slog.Logger) *StdHandler {
+	return &StdHandler{
+		logger: l,
+	}
+}
+
+// Enabled implements log/slog.Handler.
+func (h *StdHandler) Enabled(_ context.Context, level std.Level) bool {
+	return h.logger.Enabled(FromStdLevel(level))
+}
+
+// Handle implements log/slog.Handler.
+func (h *StdHandler) Handle(ctx context.Context, r std.Record) error {
+	fields := make([]slog.Field, len(h.fields), len(h.fields)+r.NumAttrs())
+	copy(fields, h.fields)
+
+	r.Attrs(func(a std.Attr) bool {
+		fields = appendAttr(fields, h.prefix, a)
+		return true
+	})
+
+	h.logger.With(fields...).
+		WithContext(ctx).
+		WithCallerPC(r.PC).
+		WithTime(r.Time).
+		Log(FromStdLevel(r.Level), r.Message)
+
+	return nil
+}
+
+// WithAttrs implements log/slog.Handler.
+func (h *StdHandler) WithAttrs(attrs []std.Attr) std.Handler {
+	c := *h
+	c.fields = make([]slog.Field, len(h.fields), len(h.fields)+len(attrs))
+	copy(c.fields, h.fields)
+
+	for _, a := range attrs {
+		c.fields = appendAttr(c.fields, h.prefix, a)
+	}
+
+	return &c
+}
+
+// WithGroup implements log/slog.Handler.
+func (h *StdHandler) WithGroup(name string) std.Handler {
+	if name == "" {
+		return h
+	}
+
+	c := *h
+	c.prefix = h.prefix + name + "."
+
+	return &c
+}
+
+// appendAttr appends a as fields with keys prefixed by prefix, following the
+// rules of log/slog.Handler for empty attributes and groups.
+func appendAttr(fields []slog.Field, prefix string, a std.Attr) []slog.Field {
+	a.Value = a.Value.Resolve()
+	if a.Equal(std.Attr{}) {
+		return fields
+	}
+
+	key := prefix + a.Key
+	v := a.Value
+
+	switch v.Kind() {
+	case std.KindGroup:
+		if a.Key != "" {
+			prefix = key + "."
+		}
+
+		for _, g := range v.Group() {
+			fields = appendAttr(fields, prefix, g)
+		}
+
+		return fields
+	case std.KindString:
+		return append(fields, slog.String(key, v.String()))
+	case std.KindInt64:
+		return append(fields, slog.Int64(key, v.Int64()))
+	case std.KindUint64:
+		return append(fields, slog.Uint64(key, v.Uint64()))
+	case std.KindFloat64:
+		return append(fields, slog.Float64(key, v.Float64()))
+	case std.KindBool:
+		return append(fields, slog.Bool(key, v.Bool()))
+	case std.KindDuration:
+		return append(fields, slog.Duration(key, v.Duration()))
+	case std.KindTime:
+		return append(fields, slog.Time(key, v.Time()))
+	}
+
+	if err, ok := v.Any().(error); ok {
+		return append(fields, slog.NamedErr(key, err))
+	}
+
+	return append(fields, slog.Any(key, v.Any()))
+}
+
+// Handler is a slog.Handler that forwards entries to a log/slog.Handler. The
+// Caller of an entry is passed on as the source of the record.
+type Handler struct {
+	handler std.Handler
+}
+
+// New returns a slog.Handler that forwards entries to h.
+func New(h std.Handler) *Handler {
+	return &Handler{
+		handler: h,
+	}
+}
+
+// HandleLog implements slog.Handler. The context of the entry, if any, is
+// passed to the log/slog.Handler.
+func (h *Handler) HandleLog(e *slog.Entry) error {
+	ctx := e.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	level := ToStdLevel(e.Level)
+	if !h.handler.Enabled(ctx, level) {
+		return nil
+	}
+
+	r := std.NewRecord(e.Time, level, e.Message, e.CallerPC())
+	for _, f := range e.AllFields() {
+		r.AddAttrs(attr(f))
+	}
+
+	return h.handler.Handle(ctx, r)
+}
+
+// attr returns f as a log/slog.Attr.
+func attr(f slog.Field) std.Attr {
+	switch f.Type {
+	case slog.StringType:
+		return std.String(f.Key, f.String)
+	case slog.IntType:
+		return std.Int64(f.Key, f.Integer)
+	case slog.UintType:
+		return std.Uint64(f.Key, f.Uint64())
+	case slog.FloatType:
+		return std.Float64(f.Key, f.Float64())
+	case slog.BoolType:
+		return std.Bool(f.Key, f.Bool())
+	case slog.DurationType:
+		return std.Duration(f.Key, f.Duration())
+	case slog.TimeType:
+		return std.Time(f.Key, f.Time())
+	}
+
+	return std.Any(f.Key, f.Interface)
+}
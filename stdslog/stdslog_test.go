@@ -0,0 +1,186 @@
+//go:build go1.21
+// +build go1.21
+
+package stdslog_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	std "log/slog"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/joshuarubin/slog"
+	"github.com/joshuarubin/slog/handlers/memory"
+	"github.com/joshuarubin/slog/stdslog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLevels(t *testing.T) {
+	levels := map[slog.Level]std.Level{
+		slog.DebugLevel: std.LevelDebug,
+		slog.InfoLevel:  std.LevelInfo,
+		slog.WarnLevel:  std.LevelWarn,
+		slog.ErrorLevel: std.LevelError,
+		slog.FatalLevel: std.LevelError + 4,
+		slog.PanicLevel: std.LevelError + 8,
+	}
+
+	for level, s := range levels {
+		assert.Equal(t, s, stdslog.ToStdLevel(level), level.String())
+		assert.Equal(t, level, stdslog.FromStdLevel(s), level.String())
+	}
+
+	assert.Equal(t, slog.DebugLevel, stdslog.FromStdLevel(std.LevelDebug-4))
+	assert.Equal(t, slog.WarnLevel, stdslog.FromStdLevel(std.LevelWarn+2))
+	assert.Equal(t, slog.PanicLevel, stdslog.FromStdLevel(std.LevelError+100))
+}
+
+func TestStdHandler(t *testing.T) {
+	h := memory.New()
+
+	l := slog.New()
+	l.RegisterHandler(slog.InfoLevel, h)
+
+	type key struct{}
+	ctx := context.WithValue(context.Background(), key{}, "value")
+
+	s := std.New(stdslog.NewStdHandler(l))
+	s.Debug("skipped")
+	s.With("app", "api").
+		WithGroup("req").
+		With("id", 1).
+		InfoContext(ctx, "request",
+			"ok", true,
+			std.Group("user", "name", "tobi", std.Group("empty")),
+			std.Group("", "inline", 1.5),
+			"took", time.Second,
+			"err", errors.New("boom"),
+		)
+	s.Log(ctx, std.LevelError+4, "fatal")
+
+	assert.Equal(t, 2, len(h.Entries))
+
+	e := h.Entries[0]
+	assert.Equal(t, slog.InfoLevel, e.Level)
+	assert.Equal(t, "request", e.Message)
+	assert.Equal(t, "value", e.Context.Value(key{}))
+	assert.Equal(t, []slog.Field{
+		slog.String("app", "api"),
+		slog.Int64("req.id", 1),
+		slog.Bool("req.ok", true),
+		slog.String("req.user.name", "tobi"),
+		slog.Float64("req.inline", 1.5),
+		slog.Duration("req.took", time.Second),
+		slog.NamedErr("req.err", errors.New("boom")),
+	}, e.FieldList)
+
+	assert.Equal(t, slog.FatalLevel, h.Entries[1].Level)
+}
+
+func TestStdHandler_caller(t *testing.T) {
+	h := memory.New()
+
+	l := slog.New().SetReportCaller(true)
+	l.RegisterHandler(slog.InfoLevel, h)
+
+	s := std.New(stdslog.NewStdHandler(l))
+	s.Info("caller")
+	_, _, line, _ := runtime.Caller(0)
+
+	if assert.NotNil(t, h.Entries[0].Caller) {
+		assert.Equal(t, "stdslog_test.go", filepath.Base(h.Entries[0].Caller.File))
+		assert.Equal(t, line-1, h.Entries[0].Caller.Line)
+		assert.Contains(t, h.Entries[0].Caller.Function, "TestStdHandler_caller")
+	}
+}
+
+func TestHandler(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := slog.New()
+	l.RegisterHandler(slog.DebugLevel, stdslog.New(std.NewJSONHandler(&buf, nil)))
+
+	l.Debug("skipped")
+	l.With(slog.String("user", "tobi"), slog.Int("id", 1)).
+		WithField("tags", []string{"a"}).
+		Warn("login")
+
+	var out map[string]interface{}
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &out))
+	delete(out, "time")
+
+	assert.Equal(t, map[string]interface{}{
+		"level": "WARN",
+		"msg":   "login",
+		"user":  "tobi",
+		"id":    1.0,
+		"tags":  []interface{}{"a"},
+	}, out)
+}
+
+func TestRoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := slog.New()
+	l.RegisterHandler(slog.InfoLevel, stdslog.New(std.NewTextHandler(&buf, &std.HandlerOptions{
+		Level: std.LevelDebug,
+		ReplaceAttr: func(groups []string, a std.Attr) std.Attr {
+			if a.Key == std.TimeKey {
+				return std.Attr{}
+			}
+
+			return a
+		},
+	})))
+
+	s := std.New(stdslog.NewStdHandler(l))
+	s.Debug("skipped")
+	s.WithGroup("req").Error("failed", "id", 1, "path", "/login")
+
+	assert.Equal(t, "level=ERROR msg=failed req.id=1 req.path=/login\n", buf.String())
+}
+
+func TestRoundTrip_timeAndSource(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := slog.New().SetReportCaller(true)
+	l.RegisterHandler(slog.InfoLevel, stdslog.New(std.NewJSONHandler(&buf, &std.HandlerOptions{
+		AddSource: true,
+	})))
+
+	var out struct {
+		Time   time.Time `json:"time"`
+		Source struct {
+			File string `json:"file"`
+			Line int    `json:"line"`
+		} `json:"source"`
+	}
+
+	// from log/slog to slog and back
+	at := time.Date(2020, 1, 2, 3, 4, 5, 6, time.UTC)
+	var pcs [1]uintptr
+	runtime.Callers(1, pcs[:])
+	_, _, line, _ := runtime.Caller(0)
+
+	r := std.NewRecord(at, std.LevelInfo, "record", pcs[0])
+	assert.NoError(t, stdslog.NewStdHandler(l).Handle(context.Background(), r))
+
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &out))
+	assert.True(t, at.Equal(out.Time), out.Time)
+	assert.Equal(t, "stdslog_test.go", filepath.Base(out.Source.File))
+	assert.Equal(t, line-1, out.Source.Line)
+
+	// from slog to log/slog
+	buf.Reset()
+	l.Info("entry")
+	_, _, line, _ = runtime.Caller(0)
+
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &out))
+	assert.Equal(t, "stdslog_test.go", filepath.Base(out.Source.File))
+	assert.Equal(t, line-1, out.Source.Line)
+}
@@ -79,20 +79,11 @@ func (e *Entry) Writer(level Level) PrefixWriteCloser {
 		level = PanicLevel
 	}
 
-	if level > DebugLevel {
-		level = DebugLevel
+	printFunc := func(msg string) {
+		e.Log(level, msg)
 	}
 
-	var printFunc func(msg string)
 	switch level {
-	case DebugLevel:
-		printFunc = e.Debug
-	case InfoLevel:
-		printFunc = e.Info
-	case WarnLevel:
-		printFunc = e.Warn
-	case ErrorLevel:
-		printFunc = e.Error
 	case FatalLevel:
 		printFunc = e.Fatal
 	case PanicLevel: